@@ -10,7 +10,7 @@ import (
 )
 
 type LoginResponse struct {
-	Metadata struct{}           `json:"metadata"`
+	Metadata Metadata           `json:"metadata"`
 	Data     auth.LoginResponse `json:"data"`
 }
 
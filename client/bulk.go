@@ -0,0 +1,131 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/item"
+)
+
+// `DefaultBulkConcurrency` is the number of requests in flight used by the bulk helpers when no cap is given.
+const DefaultBulkConcurrency = 8
+
+// `BulkError` is returned by the bulk helpers when some of the operations failed.
+// @property {[]error} Errors - The error of each operation, in the order of the inputs, nil when it succeeded.
+type BulkError struct {
+	Errors []error
+}
+
+func (e *BulkError) Error() string {
+	messages := make([]string, 0)
+	for i, err := range e.Errors {
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("[%d] %s", i, err.Error()))
+		}
+	}
+
+	return fmt.Sprintf("%d of %d operations failed: %s", len(messages), len(e.Errors), strings.Join(messages, "; "))
+}
+
+// `Failed` returns the indexes of the inputs whose operation failed.
+// @returns {[]int} The indexes of the failed operations, sorted.
+func (e *BulkError) Failed() []int {
+	result := make([]int, 0)
+	for i, err := range e.Errors {
+		if err != nil {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// `CreateItems` creates items in the API, with at most `concurrency` requests in flight.
+// Every item is attempted even if some of them fail.
+// @param {[]Item} items - The items to create.
+// @param {int} concurrency - The maximum number of requests in flight, `DefaultBulkConcurrency` if not positive.
+// @returns {[]*Item} - The created items, in the order of the inputs, nil for the items that failed.
+// @returns {error} - A `BulkError` if at least one item could not be created.
+func (c *Client) CreateItems(items []item.Item, concurrency int) ([]*item.Item, error) {
+	return runBulk(items, concurrency, c.CreateItem)
+}
+
+// `CreateContents` creates contents in the API, with at most `concurrency` requests in flight.
+// Every content is attempted even if some of them fail.
+// @param {[]Content} contents - The contents to create.
+// @param {int} concurrency - The maximum number of requests in flight, `DefaultBulkConcurrency` if not positive.
+// @returns {[]*Content} - The created contents, in the order of the inputs, nil for the contents that failed.
+// @returns {error} - A `BulkError` if at least one content could not be created.
+func (c *Client) CreateContents(contents []content.Content, concurrency int) ([]*content.Content, error) {
+	return runBulk(contents, concurrency, c.CreateContent)
+}
+
+// `DeleteMany` deletes resources from the API, with at most `concurrency` requests in flight,
+// e.g. `c.DeleteMany(IDs, 0, c.DeleteItem)`.
+// Every ID is attempted even if some of them fail.
+// @param {[]string} IDs - The IDs of the resources to delete.
+// @param {int} concurrency - The maximum number of requests in flight, `DefaultBulkConcurrency` if not positive.
+// @param remove - The function deleting a single resource, `DeleteItem`, `DeleteContent` or `DeleteModule`.
+// @returns {error} - A `BulkError` if at least one resource could not be deleted.
+func (c *Client) DeleteMany(IDs []string, concurrency int, remove func(ID string) error) error {
+	_, err := runBulk(IDs, concurrency, func(ID string) (*struct{}, error) {
+		return nil, remove(ID)
+	})
+
+	return err
+}
+
+// `runBulk` calls `run` for every input from a pool of `concurrency` workers.
+// @param {[]In} inputs - The inputs of the operations.
+// @param {int} concurrency - The number of workers, `DefaultBulkConcurrency` if not positive.
+// @param run - The function running a single operation.
+// @returns {[]*Out} - The results, in the order of the inputs, nil for the operations that failed.
+// @returns {error} - A `BulkError` holding the error of every failed operation.
+func runBulk[In any, Out any](inputs []In, concurrency int, run func(In) (*Out, error)) ([]*Out, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	if concurrency > len(inputs) {
+		concurrency = len(inputs)
+	}
+
+	results := make([]*Out, len(inputs))
+	errs := make([]error, len(inputs))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				result, err := run(inputs[i])
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range inputs {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return results, &BulkError{Errors: errs}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulk(t *testing.T) {
+	inputs := make([]int, 50)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	var inFlight, maxInFlight int32
+	results, err := runBulk(inputs, 4, func(i int) (*string, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * time.Duration(i%3))
+
+		if i%10 == 3 {
+			return nil, fmt.Errorf("input %d failed", i)
+		}
+		result := fmt.Sprintf("result %d", i)
+		return &result, nil
+	})
+
+	if maxInFlight > 4 {
+		t.Errorf("Expected at most 4 operations in flight, got %d", maxInFlight)
+	}
+
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Expected a BulkError, got %v", err)
+	}
+	failed := bulkErr.Failed()
+	if len(failed) != 5 || failed[0] != 3 || failed[4] != 43 {
+		t.Errorf("Unexpected failed operations: %v", failed)
+	}
+
+	for i, result := range results {
+		if i%10 == 3 {
+			if result != nil {
+				t.Errorf("Expected no result for failed input %d", i)
+			}
+			continue
+		}
+		if result == nil || *result != fmt.Sprintf("result %d", i) {
+			t.Errorf("Result %d is out of order: %v", i, result)
+		}
+	}
+}
+
+func TestDeleteManyEmpty(t *testing.T) {
+	c := &Client{}
+
+	err := c.DeleteMany([]string{}, 0, func(ID string) error {
+		return fmt.Errorf("must not be called")
+	})
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// `Cache` is a read-through cache of the GET responses of the API, keyed by resource path (e.g. `module/<id>`).
+// The raw responses are cached rather than the models, so that every read returns a fresh copy that the
+// caller can modify. A nil `*Cache` is a valid disabled cache.
+// @property ttl - How long an entry is served before being fetched again.
+// @property entries - The cached responses by key.
+// @property {uint64} hits - The number of reads served from the cache.
+// @property {uint64} misses - The number of reads sent to the API.
+type Cache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	hits    uint64
+	misses  uint64
+}
+
+// `cacheEntry` is a cached GET response.
+type cacheEntry struct {
+	body      []byte
+	headers   http.Header
+	expiresAt time.Time
+}
+
+// `NewCache` creates a cache whose entries expire after `ttl`.
+// @param {time.Duration} ttl - How long an entry is served before being fetched again.
+// @returns {*Cache} - The cache, nil if `ttl` is not positive so that caching is disabled.
+func NewCache(ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// `get` returns the cached response of a key, and whether it was found and not expired.
+func (c *Cache) get(key string) ([]byte, http.Header, bool) {
+	if c == nil {
+		return nil, nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+
+	if !ok {
+		c.misses++
+		return nil, nil, false
+	}
+
+	c.hits++
+	return entry.body, entry.headers, true
+}
+
+// `put` stores the response of a key.
+func (c *Cache) put(key string, body []byte, headers http.Header) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{
+		body:      body,
+		headers:   headers.Clone(),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// `Invalidate` removes the cached response of a key, the next read fetches it from the API.
+// @param {string} key - The resource path, e.g. `module/<id>`.
+func (c *Cache) Invalidate(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// `Stats` returns the number of reads served from the cache and sent to the API.
+// @returns {uint64} - The number of cache hits.
+// @returns {uint64} - The number of cache misses.
+func (c *Cache) Stats() (uint64, uint64) {
+	if c == nil {
+		return 0, 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits, c.misses
+}
+
+// `fetchCached` makes a GET request to the API through the cache of the client.
+// @param {string} key - The resource path the response is cached under, e.g. `module/<id>`.
+// @param {http.Request} req - The request to make when the response is not cached.
+// @returns {[]byte} - The response body.
+// @returns {http.Header} - The response headers.
+// @returns {error} - An error if the request could not be made, or an `APIError` if the API rejected it.
+func (client *Client) fetchCached(key string, req *http.Request) ([]byte, http.Header, error) {
+	if body, headers, ok := client.Cache.get(key); ok {
+		client.recordMetadata(req, body, headers)
+		return body, headers, nil
+	}
+
+	body, headers, err := client.fetchAPIWithHeaders(req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client.Cache.put(key, body, headers)
+
+	return body, headers, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"polycode-provider/client/models/item"
+)
+
+func TestCache(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.Header().Set("ETag", "\"1\"")
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), Cache: NewCache(time.Minute)}
+
+	first, err := c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Error getting item: %s", err)
+	}
+	first.Cost = 20
+
+	second, err := c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Error getting item: %s", err)
+	}
+	if gets != 1 {
+		t.Errorf("Expected the second read to be served from the cache, got %d requests", gets)
+	}
+	if second.Cost != 10 || second.ETag != "\"1\"" {
+		t.Errorf("Cached reads must return a fresh copy, got %+v", second)
+	}
+
+	cost := int64(30)
+	_, err = c.PatchItem("i1", item.UpdateItemRequest{Cost: &cost}, "")
+	if err != nil {
+		t.Fatalf("Error patching item: %s", err)
+	}
+	_, err = c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Error getting item: %s", err)
+	}
+	if gets != 2 {
+		t.Errorf("Expected a write to invalidate the cached item, got %d requests", gets)
+	}
+
+	hits, misses := c.Cache.Stats()
+	if hits != 1 || misses != 2 {
+		t.Errorf("Expected 1 hit and 2 misses, got %d hits and %d misses", hits, misses)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	cache := NewCache(time.Millisecond)
+	cache.put("module/m1", []byte("{}"), http.Header{})
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, _, ok := cache.get("module/m1"); ok {
+		t.Errorf("Expected the entry to be expired")
+	}
+	if NewCache(0) != nil {
+		t.Errorf("Expected a zero TTL to disable the cache")
+	}
+}
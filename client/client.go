@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"polycode-provider/client/models/auth"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // `Client` is a struct that holds all information about the client that will interact with the API.
@@ -16,36 +19,102 @@ import (
 // @property {string} AccessToken - The access token that will be used to authenticate the client.
 // @property Auth - This is the authentication credentials that will be used to authenticate the
 // client.
+// @property {[]string} DefaultTags - The tags that every module managed with this client must carry.
+// @property {bool} PlanTimeValidation - Whether the resources managed with this client are validated by the API
+// when they are planned.
+// @property {*Cache} Cache - The cache of the GET responses, nil to disable caching.
+// @property {*Limiter} Limiter - The rate and concurrency limits of the requests, nil to disable them.
+// @property Context - The context the requests that are not made with a context are sent within, its deadline
+// bounds the whole operation, nil for no deadline.
+// @property LogContext - The context holding the logger and the parent span of the requests that are not made
+// with a context, nil not to log them.
+// @property {time.Duration} RequestTimeout - The timeout of a request that is not bounded by the deadline of its
+// context, from when it is sent until its response is read. 0 for no timeout.
+// @property {time.Duration} OperationPollInterval - The delay before the first poll of an asynchronous operation
+// of the API, see `WaitForOperation`.
+// @property {OperationWaiter} OperationWaiter - The function waiting for the asynchronous operations of the API the
+// requests are accepted with, nil to wait with `WaitForOperation`.
+// @property {string} UserAgent - The `User-Agent` header of the requests, the default of Go if empty.
+// @property {*Notices} Notices - The collector of the warnings and deprecations of the responses, nil not to
+// collect them.
+// @property {[]Middleware} Middlewares - The middlewares every request goes through, the first one being the
+// outermost.
 type Client struct {
-	Host        string
-	HTTPClient  *http.Client
-	AccessToken string
-	Auth        auth.Credentials
+	Host                  string
+	HTTPClient            *http.Client
+	AccessToken           string
+	Auth                  auth.Credentials
+	DefaultTags           []string
+	PlanTimeValidation    bool
+	Cache                 *Cache
+	Limiter               *Limiter
+	Context               context.Context
+	LogContext            context.Context
+	RequestTimeout        time.Duration
+	OperationPollInterval time.Duration
+	OperationWaiter       OperationWaiter
+	UserAgent             string
+	Middlewares           []Middleware
+	Notices               *Notices
 }
 
-// `NewClient` creates a new client for interacting with the API
-// @param {string} host - The hostname of the API server.
-// @param {string} username - The username to use for authentication.
-// @param {string} password - The password to use for authentication.
+// `DefaultRequestTimeout` is the timeout of a request sent without a deadline, e.g. outside of a Terraform operation.
+const DefaultRequestTimeout = 10 * time.Second
+
+// `New` creates a new client for interacting with the API, configured with functional options,
+// e.g. `New(WithHost("https://api.polycode.do-2021.fr"), WithToken(token))`.
+// The client logs in when it is given credentials and no access token.
+// @param {...Option} options - The options of the client, applied in order.
 // @returns {Client} - The client that will be used to interact with the API.
 // @returns {error} - An error if the client could not be created.
-func NewClient(host, username, password *string) (*Client, error) {
+func New(options ...Option) (*Client, error) {
 	defaultHost := "http://localhost:3000"
 	if os.Getenv("POLYCODE_HOST") != "" {
 		defaultHost = os.Getenv("POLYCODE_HOST")
 	}
 
 	client := Client{
-		Host:       defaultHost,
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Host:                  defaultHost,
+		HTTPClient:            &http.Client{},
+		RequestTimeout:        DefaultRequestTimeout,
+		OperationPollInterval: DefaultOperationPollInterval,
+	}
+
+	for _, option := range options {
+		option(&client)
+	}
+
+	if client.AccessToken != "" || client.Auth == (auth.Credentials{}) {
+		return &client, nil
+	}
+
+	err := client.authenticate()
+	if err != nil {
+		return nil, err
 	}
 
+	return &client, nil
+}
+
+// `NewClient` creates a new client for interacting with the API
+// @param {string} host - The hostname of the API server.
+// @param {string} username - The username to use for authentication.
+// @param {string} password - The password to use for authentication.
+// @returns {Client} - The client that will be used to interact with the API.
+// @returns {error} - An error if the client could not be created.
+func NewClient(host, username, password *string) (*Client, error) {
+	options := make([]Option, 0)
 	if host != nil {
-		client.Host = *host
+		options = append(options, WithHost(*host))
+	}
+
+	client, err := New(options...)
+	if err != nil {
+		return nil, err
 	}
 
 	if username == nil || password == nil {
-		return &client, nil
+		return client, nil
 	}
 
 	client.Auth = auth.Credentials{
@@ -53,25 +122,88 @@ func NewClient(host, username, password *string) (*Client, error) {
 		Password: *password,
 	}
 
-	token, err := client.Login()
+	err = client.authenticate()
 	if err != nil {
 		return nil, err
 	}
+
+	return client, nil
+}
+
+// `authenticate` logs the client in with its credentials and keeps the access token.
+// @returns {error} - An error if the client could not be authenticated.
+func (client *Client) authenticate() error {
+	token, err := client.Login()
+	if err != nil {
+		return err
+	}
 	if token == nil {
-		return nil, fmt.Errorf("access token nil")
+		return fmt.Errorf("access token nil")
 	}
 
 	client.AccessToken = *token
 
-	return &client, nil
+	return nil
+}
+
+// `WithContext` returns a copy of the client whose requests are sent, logged and traced within the given context,
+// sharing the HTTP client, the cache and the limiter of the original client. The requests are canceled when the
+// context is done, so the deadline of the context is the timeout of the whole operation.
+// @param ctx - The context of the operation making the requests.
+// @returns {*Client} - The copy of the client.
+func (client *Client) WithContext(ctx context.Context) *Client {
+	result := *client
+	result.Context = ctx
+	result.LogContext = ctx
+
+	return &result
 }
 
 // `fetchAPI` is a function that is used to make requests to the API adding every needed headers to the request.
 // @param {http.Request} req - The request to make.
 // @param {string} authToken - The access token to use for authentication.
 // @returns {[]byte} - The response body.
-// @returns {error} - An error if the request could not be made.
+// @returns {error} - An error if the request could not be made, or an `APIError` if the API rejected it.
 func (client *Client) fetchAPI(req *http.Request, authToken *string) ([]byte, error) {
+	body, _, err := client.fetchAPIWithHeaders(req, authToken)
+
+	return body, err
+}
+
+// `fetchAPIWithHeaders` makes a request to the API like `fetchAPI` and also returns the response headers.
+// When the API accepts the request with 202 Accepted and processes it asynchronously, the operation is waited
+// for, see `WaitForOperation`, and the resource it created or updated is returned.
+// @param {http.Request} req - The request to make.
+// @param {string} authToken - The access token to use for authentication.
+// @returns {[]byte} - The response body.
+// @returns {http.Header} - The response headers.
+// @returns {error} - An error if the request could not be made, an `APIError` if the API rejected it, or an
+// `OperationError` if the operation of the API failed.
+func (client *Client) fetchAPIWithHeaders(req *http.Request, authToken *string) ([]byte, http.Header, error) {
+	statusCode, body, headers, err := client.fetch(req, authToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if statusCode == http.StatusAccepted {
+		return client.awaitOperation(req, body, headers)
+	}
+
+	return body, headers, nil
+}
+
+// `fetch` makes a request to the API, retrying it while the API rate limit is reached.
+// @param {http.Request} req - The request to make.
+// @param {string} authToken - The access token to use for authentication.
+// @returns {int} - The status code of the response, one of the successful ones.
+// @returns {[]byte} - The response body.
+// @returns {http.Header} - The response headers.
+// @returns {error} - An error if the request could not be made, or an `APIError` if the API rejected it.
+func (client *Client) fetch(req *http.Request, authToken *string) (int, []byte, http.Header, error) {
+	if client.Context != nil && req.Context() == context.Background() {
+		req = req.WithContext(client.Context)
+	}
+
 	token := client.AccessToken
 
 	if authToken != nil {
@@ -80,20 +212,94 @@ func (client *Client) fetchAPI(req *http.Request, authToken *string) ([]byte, er
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Content-Type", "application/json")
-	res, err := client.HTTPClient.Do(req)
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
+
+	for attempt := 0; ; attempt++ {
+		statusCode, body, headers, err := client.send(req)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		if statusCode == http.StatusTooManyRequests && attempt < MaxRateLimitRetries && (req.Body == nil || req.GetBody != nil) {
+			// Every request of the client waits, not only this one, and the retry goes through the limiter again.
+			// Without a limiter, only this request waits.
+			wait := retryAfter(headers, attempt)
+			if client.Limiter != nil {
+				client.Limiter.pause(wait)
+			} else if err := sleep(req.Context(), wait); err != nil {
+				return 0, nil, nil, err
+			}
+			tflog.Debug(client.logContext(req), "API rate limit reached, retrying", map[string]interface{}{
+				"method":     req.Method,
+				"path":       req.URL.Path,
+				"request_id": req.Header.Get(RequestIDHeader),
+				"attempt":    attempt + 1,
+				"wait":       wait.String(),
+			})
+
+			if req.GetBody != nil {
+				req.Body, err = req.GetBody()
+				if err != nil {
+					return 0, nil, nil, err
+				}
+			}
+			continue
+		}
+
+		if statusCode != http.StatusOK && statusCode != http.StatusCreated && statusCode != http.StatusAccepted && statusCode != http.StatusNoContent {
+			return 0, nil, nil, newAPIError(statusCode, body)
+		}
+
+		client.recordMetadata(req, body, headers)
+
+		return statusCode, body, headers, nil
+	}
+}
+
+// `send` sends a request to the API once it is allowed by the limiter of the client, and reads the response.
+// @param {http.Request} req - The request to send.
+// @returns {int} - The status code of the response.
+// @returns {[]byte} - The response body.
+// @returns {http.Header} - The response headers.
+// @returns {error} - An error if the request could not be sent or the response could not be read.
+func (client *Client) send(req *http.Request) (int, []byte, http.Header, error) {
+	err := client.Limiter.acquire(req.Context())
 	if err != nil {
-		return nil, err
+		return 0, nil, nil, err
 	}
-	defer res.Body.Close()
+	defer client.Limiter.release()
 
-	body, err := io.ReadAll(res.Body)
+	if req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
+
+	ctx, span := startSpan(client.logContext(req), req)
+	logRequest(ctx, req)
+	start := time.Now()
+
+	// Within an operation, its deadline is the only timeout, so that a slow request can use all of it.
+	if _, ok := req.Context().Deadline(); !ok && client.RequestTimeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(req.Context(), client.RequestTimeout)
+		defer cancel()
+		req = req.WithContext(timeoutCtx)
+	}
+
+	res, err := client.roundTrip(req)
 	if err != nil {
-		return nil, err
+		logResponse(ctx, req, nil, nil, time.Since(start), err)
+		endSpan(span, 0, err)
+		return 0, nil, nil, err
 	}
+	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusNoContent {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, string(body))
+	body, err := io.ReadAll(res.Body)
+	logResponse(ctx, req, res, body, time.Since(start), err)
+	endSpan(span, res.StatusCode, err)
+	if err != nil {
+		return 0, nil, nil, err
 	}
 
-	return body, err
+	return res.StatusCode, body, res.Header, nil
 }
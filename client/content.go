@@ -9,7 +9,7 @@ import (
 )
 
 type GetContentResponse struct {
-	Metadata struct{}                  `json:"metadata"`
+	Metadata Metadata                  `json:"metadata"`
 	Data     models.GetContentResponse `json:"data"`
 }
 
@@ -27,7 +27,7 @@ func (client *Client) GetContent(ID string) (*models.Content, error) {
 		return nil, err
 	}
 
-	body, err := client.fetchAPI(req, nil)
+	body, headers, err := client.fetchCached(fmt.Sprintf("content/%s", ID), req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,10 @@ func (client *Client) GetContent(ID string) (*models.Content, error) {
 		return nil, err
 	}
 
-	return contentResponse.Data.IntoContent(), nil
+	result := contentResponse.Data.IntoContent()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 type CreateContentResponse struct {
@@ -60,7 +63,7 @@ func (client *Client) CreateContent(content models.Content) (*models.Content, er
 		return nil, err
 	}
 
-	body, err = client.fetchAPI(req, nil)
+	body, headers, err := client.fetchAPIWithHeaders(req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +74,10 @@ func (client *Client) CreateContent(content models.Content) (*models.Content, er
 		return nil, err
 	}
 
-	return contentResponse.Data.IntoContent(), nil
+	result := contentResponse.Data.IntoContent()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 type UpdateContentResponse struct {
@@ -79,6 +85,8 @@ type UpdateContentResponse struct {
 }
 
 // `UpdateContent` updates a content in the API.
+// If the content holds an ETag, the update is rejected with an `APIErrorPreconditionFailed` error
+// if the content changed since it was read.
 // @param {Content} content - The content to update.
 // @returns {Content} - The content that was updated.
 // @returns {error} - An error if there was a problem updating the content.
@@ -96,8 +104,12 @@ func (client *Client) UpdateContent(content models.Content) (*models.Content, er
 	if err != nil {
 		return nil, err
 	}
+	if content.ETag != "" {
+		req.Header.Set("If-Match", content.ETag)
+	}
 
-	body, err = client.fetchAPI(req, nil)
+	body, headers, err := client.fetchAPIWithHeaders(req, nil)
+	client.Cache.Invalidate(fmt.Sprintf("content/%s", content.ID))
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +120,10 @@ func (client *Client) UpdateContent(content models.Content) (*models.Content, er
 		return nil, err
 	}
 
-	return contentResponse.Data.IntoContent(), nil
+	result := contentResponse.Data.IntoContent()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 // `DeleteContent` deletes a content from the API.
@@ -125,9 +140,38 @@ func (client *Client) DeleteContent(ID string) error {
 	}
 
 	_, err = client.fetchAPI(req, nil)
+	client.Cache.Invalidate(fmt.Sprintf("content/%s", ID))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// `ValidateContent` asks the API whether it would accept a content, without creating or updating it.
+// A content holding an ID is validated as an update of that content, otherwise as a new content.
+// @param {Content} content - The content to validate.
+// @returns {error} - An `APIErrorBadRequest` error holding the rules the content breaks, see `ValidationErrors`,
+// or an error if there was a problem validating the content.
+func (client *Client) ValidateContent(content models.Content) error {
+	url := fmt.Sprintf("%s/content/validate", client.Host)
+	var request interface{} = content.IntoCreateContentRequest()
+	if content.ID != "" {
+		url = fmt.Sprintf("%s/content/%s/validate", client.Host, content.ID)
+		request = content.IntoUpdateContentRequest()
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = client.fetchAPI(req, nil)
+
+	return err
+}
@@ -23,10 +23,10 @@ func TestExerciseLifecycle(t *testing.T) {
 	}
 
 	i := item.Item{
-		Type: "hint",
+		Type: item.TypeHint,
 		Cost: 10,
 		Data: item.ItemData{
-			Text: "This is a test hint",
+			Hint: &item.HintData{Text: "This is a test hint"},
 		},
 	}
 
@@ -115,7 +115,12 @@ func TestExerciseLifecycle(t *testing.T) {
 		Description: "This is an updated test content",
 		Type:        "exercise",
 		Reward:      100,
-		Data:        content.ContentData{},
+		Data: content.ContentData{
+			Difficulty:         content.DifficultyEasy,
+			EstimatedMinutes:   20,
+			Topics:             []string{"io", "strings"},
+			LearningObjectives: []string{"Print to stdout"},
+		},
 		RootComponent: content.Component{
 			ID:          createdContent.RootComponent.ID,
 			Type:        "container",
@@ -196,6 +201,18 @@ func TestExerciseLifecycle(t *testing.T) {
 	if updatedContent.Reward != newContent.Reward {
 		t.Errorf("Error checking updated content: field Reward expected %d got %d", newContent.Reward, updatedContent.Reward)
 	}
+	if updatedContent.Data.Difficulty != newContent.Data.Difficulty {
+		t.Errorf("Error checking updated content: field Data.Difficulty expected '%s' got '%s'", newContent.Data.Difficulty, updatedContent.Data.Difficulty)
+	}
+	if updatedContent.Data.EstimatedMinutes != newContent.Data.EstimatedMinutes {
+		t.Errorf("Error checking updated content: field Data.EstimatedMinutes expected %d got %d", newContent.Data.EstimatedMinutes, updatedContent.Data.EstimatedMinutes)
+	}
+	if len(updatedContent.Data.Topics) != len(newContent.Data.Topics) {
+		t.Errorf("Error checking updated content: field Data.Topics expected %v got %v", newContent.Data.Topics, updatedContent.Data.Topics)
+	}
+	if len(updatedContent.Data.LearningObjectives) != len(newContent.Data.LearningObjectives) {
+		t.Errorf("Error checking updated content: field Data.LearningObjectives expected %v got %v", newContent.Data.LearningObjectives, updatedContent.Data.LearningObjectives)
+	}
 	if updatedContent.RootComponent.Data.Components[0].Data.Markdown != newContent.RootComponent.Data.Components[0].Data.Markdown {
 		t.Errorf("Error checking updated content: field RootComponent.Data.Components[0].Data.Markdown expected %s got %s", newContent.RootComponent.Data.Components[0].Data.Markdown, updatedContent.RootComponent.Data.Components[0].Data.Markdown)
 	}
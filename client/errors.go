@@ -0,0 +1,68 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kinds of errors returned by the API.
+const (
+	APIErrorUnknown            = "unknown"
+	APIErrorBadRequest         = "bad_request"
+	APIErrorUnauthorized       = "unauthorized"
+	APIErrorNotFound           = "not_found"
+	APIErrorPreconditionFailed = "precondition_failed"
+	APIErrorTooManyRequests    = "too_many_requests"
+)
+
+// `APIError` is an error returned by the API with a non successful status code.
+// @property {int} StatusCode - The HTTP status code of the response.
+// @property {string} Kind - The kind of error, see the `APIError*` constants.
+// @property {string} Body - The body of the response.
+type APIError struct {
+	StatusCode int
+	Kind       string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// `newAPIError` creates an `APIError` from a status code and a response body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	kind := APIErrorUnknown
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		kind = APIErrorBadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		kind = APIErrorUnauthorized
+	case http.StatusNotFound:
+		kind = APIErrorNotFound
+	case http.StatusPreconditionFailed:
+		kind = APIErrorPreconditionFailed
+	case http.StatusTooManyRequests:
+		kind = APIErrorTooManyRequests
+	}
+
+	return &APIError{
+		StatusCode: statusCode,
+		Kind:       kind,
+		Body:       string(body),
+	}
+}
+
+// `IsAPIError` tells whether an error is an `APIError` of the given kind.
+// @param {error} err - The error to check.
+// @param {string} kind - The kind of error, see the `APIError*` constants.
+// @returns {bool} - Whether the error is of the given kind.
+func IsAPIError(err error, kind string) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.Kind == kind
+}
@@ -0,0 +1,321 @@
+package exercise
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/shared"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Files and folders of an exercise package.
+const (
+	ManifestFile  = "polycode.yaml"
+	StatementFile = "statement.md"
+	StarterDir    = "starter"
+	SolutionDir   = "solution"
+	TestsDir      = "tests"
+)
+
+// `Manifest` is the `polycode.yaml` file of an exercise package.
+// @property {int64} Reward - The amount of points the user will receive for completing the exercise.
+// @property {string} Layout - The orientation of the exercise, `horizontal` (default) or `vertical`.
+// @property {map[string]ManifestLanguage} Languages - The settings of each language, keyed by language.
+// @property {[]string} HiddenTests - The names of the tests hidden to the user, e.g. `03`.
+type Manifest struct {
+	Reward      int64                       `yaml:"reward"`
+	Layout      string                      `yaml:"layout"`
+	Languages   map[string]ManifestLanguage `yaml:"languages"`
+	HiddenTests []string                    `yaml:"hidden_tests"`
+}
+
+// `ManifestLanguage` is the settings of a language in the manifest.
+// @property {string} Version - The version of the language.
+type ManifestLanguage struct {
+	Version string `yaml:"version"`
+}
+
+// `Package` is an exercise loaded from a directory.
+// @property {Content} Content - The content of the exercise, without its name, description and type.
+// @property {map[string]string} Solutions - The solution of each language, keyed by language.
+// The API does not store solutions, they are only used to check the exercise locally.
+// @property {string} Hash - The hash of every file of the package, it changes whenever a file changes.
+type Package struct {
+	Content   content.Content
+	Solutions map[string]string
+	Hash      string
+}
+
+// `Load` reads an exercise package from a directory.
+// A starter or solution file is named after its language in lower case, e.g. `starter/python.py`.
+// The content holds a root container with the statement as a markdown component, followed by
+// an editor component with a language for each starter or solution file and a validator for
+// each `tests/NN.in` and `tests/NN.out` pair, sorted by name.
+// @param {string} dir - The directory of the package.
+// @returns {*Package} - The loaded package.
+// @returns {error} - An error if a file is missing or invalid.
+func Load(dir string) (*Package, error) {
+	manifest := Manifest{Layout: "horizontal"}
+
+	raw, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		err = yaml.Unmarshal(raw, &manifest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+		}
+	}
+	if manifest.Layout != "horizontal" && manifest.Layout != "vertical" {
+		return nil, fmt.Errorf("invalid %s: layout must be horizontal or vertical", ManifestFile)
+	}
+	for language := range manifest.Languages {
+		if !content.IsLanguage(language) {
+			return nil, fmt.Errorf("invalid %s: unknown language %q, expected one of %s", ManifestFile, language, strings.Join(content.Languages, ", "))
+		}
+	}
+
+	statement, err := os.ReadFile(filepath.Join(dir, StatementFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the statement: %w", err)
+	}
+
+	starters, err := readLanguageFiles(filepath.Join(dir, StarterDir))
+	if err != nil {
+		return nil, err
+	}
+	solutions, err := readLanguageFiles(filepath.Join(dir, SolutionDir))
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := readTests(filepath.Join(dir, TestsDir), manifest.HiddenTests)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]content.Language, 0)
+	for _, language := range content.Languages {
+		_, hasStarter := starters[language]
+		_, hasSolution := solutions[language]
+		_, hasSettings := manifest.Languages[language]
+		if !hasStarter && !hasSolution && !hasSettings {
+			continue
+		}
+
+		languages = append(languages, content.Language{
+			DefaultCode: starters[language],
+			Language:    language,
+			Version:     manifest.Languages[language].Version,
+		})
+	}
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("no language found, add a file to %s/ or %s/", StarterDir, SolutionDir)
+	}
+
+	hash, err := Hash(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Content: content.Content{
+			Reward: manifest.Reward,
+			RootComponent: content.Component{
+				Type:        "container",
+				Orientation: manifest.Layout,
+				Data: content.ComponentData{
+					Components: []content.Component{
+						{
+							Type: "markdown",
+							Data: content.ComponentData{
+								Markdown: string(statement),
+							},
+						},
+						{
+							Type: "editor",
+							Data: content.ComponentData{
+								EditorSettings: content.EditorSettings{
+									Languages: languages,
+								},
+								Validators: validators,
+								Items:      make([]content.ItemIdentifier, 0),
+							},
+						},
+					},
+				},
+			},
+		},
+		Solutions: solutions,
+		Hash:      hash,
+	}, nil
+}
+
+// `Hash` computes the hash of the files of a package directory, hidden files excluded.
+// @param {string} dir - The directory of the package.
+// @returns {string} - The hex encoded SHA-256 of the relative paths and contents of the files.
+// @returns {error} - An error if a file could not be read.
+func Hash(dir string) (string, error) {
+	paths := make([]string, 0)
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(paths)
+
+	hash := sha256.New()
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(rel), len(raw))
+		hash.Write(raw)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// `readLanguageFiles` reads the files of a starter or solution directory, keyed by language.
+// The directory is optional, a file must be named after its language, e.g. `python.py`.
+func readLanguageFiles(dir string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		language := strings.ToUpper(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+		if !content.IsLanguage(language) {
+			return nil, fmt.Errorf("%s: unknown language %q, expected one of %s", filepath.Join(filepath.Base(dir), entry.Name()), language, strings.Join(content.Languages, ", "))
+		}
+		if _, ok := result[language]; ok {
+			return nil, fmt.Errorf("%s: more than one file for language %s", filepath.Base(dir), language)
+		}
+
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result[language] = string(raw)
+	}
+
+	return result, nil
+}
+
+// `readTests` reads the `NN.in` and `NN.out` pairs of the tests directory into validators,
+// sorted by name. Each line of a file is a stdin or stdout entry.
+func readTests(dir string, hidden []string) ([]content.Validator, error) {
+	result := make([]content.Validator, 0)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	inputs := make(map[string]bool)
+	outputs := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		switch filepath.Ext(entry.Name()) {
+		case ".in":
+			inputs[name] = true
+		case ".out":
+			outputs[name] = true
+		default:
+			return nil, fmt.Errorf("%s/%s: test files must end with .in or .out", TestsDir, entry.Name())
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !inputs[name] {
+			return nil, fmt.Errorf("%s/%s.out has no matching %s.in", TestsDir, name, name)
+		}
+		if !outputs[name] {
+			return nil, fmt.Errorf("%s/%s.in has no matching %s.out", TestsDir, name, name)
+		}
+
+		input, err := os.ReadFile(filepath.Join(dir, name+".in"))
+		if err != nil {
+			return nil, err
+		}
+		output, err := os.ReadFile(filepath.Join(dir, name+".out"))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, content.Validator{
+			IsHidden: shared.ContainsString(hidden, name),
+			Input:    content.ValidatorInput{Stdin: splitLines(string(input))},
+			Output:   content.ValidatorOutput{Stdout: splitLines(string(output))},
+		})
+	}
+
+	for _, name := range hidden {
+		if !inputs[name] {
+			return nil, fmt.Errorf("invalid %s: hidden test %q does not exist", ManifestFile, name)
+		}
+	}
+
+	return result, nil
+}
+
+// `splitLines` splits a text file into lines, without the trailing newline.
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return make([]string, 0)
+	}
+
+	return strings.Split(text, "\n")
+}
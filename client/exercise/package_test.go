@@ -0,0 +1,130 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePackage(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"polycode.yaml":      "reward: 50\nlayout: vertical\nlanguages:\n  PYTHON:\n    version: \"3.10\"\nhidden_tests: [\"02\"]\n",
+		"statement.md":       "# Sum\n\nPrint the sum of two numbers.\n",
+		"starter/python.py":  "a = int(input())\n",
+		"solution/python.py": "print(int(input()) + int(input()))\n",
+		"solution/rust.rs":   "fn main() {}\n",
+		"tests/01.in":        "1\n2\n",
+		"tests/01.out":       "3\n",
+		"tests/02.in":        "40\n2\n",
+		"tests/02.out":       "42\n",
+	})
+
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Error loading package: %s", err)
+	}
+
+	root := pkg.Content.RootComponent
+	if pkg.Content.Reward != 50 || root.Orientation != "vertical" {
+		t.Errorf("Manifest not applied: reward %d, layout %s", pkg.Content.Reward, root.Orientation)
+	}
+	if len(root.Data.Components) != 2 {
+		t.Fatalf("Expected a markdown and an editor component, got %d components", len(root.Data.Components))
+	}
+	if root.Data.Components[0].Data.Markdown != "# Sum\n\nPrint the sum of two numbers.\n" {
+		t.Errorf("Unexpected statement: %q", root.Data.Components[0].Data.Markdown)
+	}
+
+	editor := root.Data.Components[1].Data
+	languages := editor.EditorSettings.Languages
+	if len(languages) != 2 || languages[0].Language != "PYTHON" || languages[1].Language != "RUST" {
+		t.Fatalf("Unexpected languages: %+v", languages)
+	}
+	if languages[0].DefaultCode != "a = int(input())\n" || languages[0].Version != "3.10" || languages[1].DefaultCode != "" {
+		t.Errorf("Unexpected language settings: %+v", languages)
+	}
+	if pkg.Solutions["RUST"] != "fn main() {}\n" {
+		t.Errorf("Unexpected solutions: %+v", pkg.Solutions)
+	}
+
+	if len(editor.Validators) != 2 {
+		t.Fatalf("Expected 2 validators, got %d", len(editor.Validators))
+	}
+	first := editor.Validators[0]
+	if first.IsHidden || len(first.Input.Stdin) != 2 || first.Input.Stdin[1] != "2" || first.Output.Stdout[0] != "3" {
+		t.Errorf("Unexpected first validator: %+v", first)
+	}
+	if !editor.Validators[1].IsHidden {
+		t.Errorf("Second validator must be hidden")
+	}
+
+	hash := pkg.Hash
+
+	err = os.WriteFile(filepath.Join(dir, "tests", "01.out"), []byte("4\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := Hash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == hash {
+		t.Errorf("Hash must change when a file changes")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	cases := map[string]map[string]string{
+		"missing statement": {
+			"starter/python.py": "",
+		},
+		"no language": {
+			"statement.md": "# Title\n",
+		},
+		"unknown language": {
+			"statement.md":     "# Title\n",
+			"starter/cobol.cb": "",
+		},
+		"missing output": {
+			"statement.md":      "# Title\n",
+			"starter/python.py": "",
+			"tests/01.in":       "1\n",
+		},
+		"unknown hidden test": {
+			"polycode.yaml":     "hidden_tests: [\"09\"]\n",
+			"statement.md":      "# Title\n",
+			"starter/python.py": "",
+		},
+		"invalid layout": {
+			"polycode.yaml":     "layout: diagonal\n",
+			"statement.md":      "# Title\n",
+			"starter/python.py": "",
+		},
+	}
+
+	for name, files := range cases {
+		_, err := Load(writePackage(t, files))
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type GetItemResponse struct {
-	Metadata struct{}               `json:"metadata"`
+	Metadata Metadata               `json:"metadata"`
 	Data     models.GetItemResponse `json:"data"`
 }
 
@@ -27,7 +27,7 @@ func (client *Client) GetItem(ID string) (*models.Item, error) {
 		return nil, err
 	}
 
-	body, err := client.fetchAPI(req, nil)
+	body, headers, err := client.fetchCached(fmt.Sprintf("item/%s", ID), req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,10 @@ func (client *Client) GetItem(ID string) (*models.Item, error) {
 		return nil, err
 	}
 
-	return itemResponse.Data.IntoItem(), nil
+	result := itemResponse.Data.IntoItem()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 type CreateItemResponse struct {
@@ -50,6 +53,10 @@ type CreateItemResponse struct {
 // @returns {Item} - The item that was created.
 // @returns {error} - An error if there was a problem creating the item.
 func (client *Client) CreateItem(item models.Item) (*models.Item, error) {
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
 	body, err := json.Marshal(item.IntoCreateItemRequest())
 	if err != nil {
 		return nil, err
@@ -60,7 +67,7 @@ func (client *Client) CreateItem(item models.Item) (*models.Item, error) {
 		return nil, err
 	}
 
-	body, err = client.fetchAPI(req, nil)
+	body, headers, err := client.fetchAPIWithHeaders(req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -71,14 +78,17 @@ func (client *Client) CreateItem(item models.Item) (*models.Item, error) {
 		return nil, err
 	}
 
-	return itemResponse.Data.IntoItem(), nil
+	result := itemResponse.Data.IntoItem()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 type UpdateItemResponse struct {
 	GetItemResponse
 }
 
-// `UpdateItem` updates an item in the API.
+// `UpdateItem` updates every property of an item in the API.
 // @param {Item} item - The item to update.
 // @returns {Item} - The item that was updated.
 // @returns {error} - An error if there was a problem updating the item.
@@ -87,17 +97,44 @@ func (client *Client) UpdateItem(item models.Item) (*models.Item, error) {
 		return nil, fmt.Errorf("empty ID")
 	}
 
-	body, err := json.Marshal(item.IntoUpdateItemRequest())
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	return client.PatchItem(item.ID, item.IntoUpdateItemRequest(), item.ETag)
+}
+
+// `PatchItem` updates an item in the API, sending only the properties that are set in the request.
+// @param {string} ID - The ID of the item to update.
+// @param {UpdateItemRequest} request - The properties to update.
+// @param {string} etag - The ETag of the item when it was last read, the update is rejected with
+// an `APIErrorPreconditionFailed` error if the item changed since. Empty to update unconditionally.
+// @returns {Item} - The item that was updated.
+// @returns {error} - An error if there was a problem updating the item.
+func (client *Client) PatchItem(ID string, request models.UpdateItemRequest, etag string) (*models.Item, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("empty ID")
+	}
+
+	if request.IsEmpty() {
+		return client.GetItem(ID)
+	}
+
+	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/item/%s", client.Host, item.ID), bytes.NewBuffer(body))
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/item/%s", client.Host, ID), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
-	body, err = client.fetchAPI(req, nil)
+	body, headers, err := client.fetchAPIWithHeaders(req, nil)
+	client.Cache.Invalidate(fmt.Sprintf("item/%s", ID))
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +145,10 @@ func (client *Client) UpdateItem(item models.Item) (*models.Item, error) {
 		return nil, err
 	}
 
-	return itemResponse.Data.IntoItem(), nil
+	result := itemResponse.Data.IntoItem()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 // `DeleteItem` deletes an item from the API.
@@ -125,6 +165,7 @@ func (client *Client) DeleteItem(ID string) error {
 	}
 
 	_, err = client.fetchAPI(req, nil)
+	client.Cache.Invalidate(fmt.Sprintf("item/%s", ID))
 	if err != nil {
 		return err
 	}
@@ -22,10 +22,10 @@ func TestHintLifecycle(t *testing.T) {
 	}
 
 	i := item.Item{
-		Type: "hint",
+		Type: item.TypeHint,
 		Cost: 10,
 		Data: item.ItemData{
-			Text: "This is a test hint",
+			Hint: &item.HintData{Text: "This is a test hint"},
 		},
 	}
 
@@ -41,10 +41,10 @@ func TestHintLifecycle(t *testing.T) {
 
 	newItem := item.Item{
 		ID:   createdItem.ID,
-		Type: "hint",
+		Type: item.TypeHint,
 		Cost: 100,
 		Data: item.ItemData{
-			Text: "This is an updated test hint",
+			Hint: &item.HintData{Text: "This is an updated test hint"},
 		},
 	}
 
@@ -61,8 +61,8 @@ func TestHintLifecycle(t *testing.T) {
 	if updatedItem.Cost != newItem.Cost {
 		t.Errorf("Error checking updated hint: field Cost expected %d got %d", newItem.Cost, updatedItem.Cost)
 	}
-	if updatedItem.Data.Text != newItem.Data.Text {
-		t.Errorf("Error checking updated hint: field Text expected '%s' got '%s'", newItem.Data.Text, updatedItem.Data.Text)
+	if updatedItem.Data.Hint.Text != newItem.Data.Hint.Text {
+		t.Errorf("Error checking updated hint: field Text expected '%s' got '%s'", newItem.Data.Hint.Text, updatedItem.Data.Hint.Text)
 	}
 
 	err = c.DeleteItem(updatedItem.ID)
@@ -70,3 +70,69 @@ func TestHintLifecycle(t *testing.T) {
 		t.Errorf("Error deleting hint: %s", err)
 	}
 }
+
+func TestTimeExtensionLifecycle(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	i := item.Item{
+		Type: item.TypeTimeExtension,
+		Cost: 50,
+		Data: item.ItemData{
+			TimeExtension: &item.TimeExtensionData{Minutes: 15},
+		},
+	}
+
+	res, err := c.CreateItem(i)
+	if err != nil {
+		t.Errorf("Error creating time extension: %s", err)
+	}
+
+	createdItem, err := c.GetItem(res.ID)
+	if err != nil {
+		t.Errorf("Error reading created time extension: %s", err)
+	}
+
+	if createdItem.Type != item.TypeTimeExtension {
+		t.Errorf("Error checking created time extension: field Type expected %s got %s", item.TypeTimeExtension, createdItem.Type)
+	}
+	if createdItem.Data.TimeExtension == nil || createdItem.Data.TimeExtension.Minutes != 15 {
+		t.Errorf("Error checking created time extension: field Minutes expected %d got %+v", 15, createdItem.Data.TimeExtension)
+	}
+
+	err = c.DeleteItem(createdItem.ID)
+	if err != nil {
+		t.Errorf("Error deleting time extension: %s", err)
+	}
+}
+
+func TestItemTypeMismatch(t *testing.T) {
+	c, err := NewClient(nil, nil, nil)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	i := item.Item{
+		Type: item.TypeRetryToken,
+		Cost: 10,
+		Data: item.ItemData{
+			Hint: &item.HintData{Text: "This is not a retry token"},
+		},
+	}
+
+	_, err = c.CreateItem(i)
+	if err == nil {
+		t.Errorf("Error checking item type mismatch: expected an error, got nil")
+	}
+}
@@ -0,0 +1,200 @@
+package lint
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/shared"
+)
+
+// Rules checked by the linter, a rule can be suppressed by its name.
+const (
+	RuleEditorWithoutValidators    = "editor-without-validators"
+	RuleEditorAllValidatorsHidden  = "editor-all-validators-hidden"
+	RuleEmptyMarkdown              = "empty-markdown"
+	RuleDuplicateValidators        = "duplicate-validators"
+	RuleLanguageWithoutDefaultCode = "language-without-default-code"
+	RuleBrokenRelativeLink         = "broken-relative-link"
+	RuleMissingTopHeading          = "missing-top-heading"
+)
+
+// `Rules` lists every rule checked by the linter.
+var Rules = []string{
+	RuleEditorWithoutValidators,
+	RuleEditorAllValidatorsHidden,
+	RuleEmptyMarkdown,
+	RuleDuplicateValidators,
+	RuleLanguageWithoutDefaultCode,
+	RuleBrokenRelativeLink,
+	RuleMissingTopHeading,
+}
+
+// `Finding` is a problem found by the linter.
+// @property {string} Rule - The name of the rule, see the `Rule*` constants.
+// @property {string} Path - The path of the offending component, in the shape of the content request,
+// e.g. `rootComponent.data.components[1]`.
+// @property {string} Message - A description of the problem.
+type Finding struct {
+	Rule    string
+	Path    string
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s (%s)", f.Path, f.Message, f.Rule)
+}
+
+// `Options` configures the linter.
+// @property {string} BaseDir - The directory relative links of the markdown are resolved from,
+// relative links are not checked when it is empty.
+// @property {[]string} Ignore - The names of the rules to suppress.
+type Options struct {
+	BaseDir string
+	Ignore  []string
+}
+
+// `IsRule` tells whether a name is the name of a rule.
+// @param {string} name - The name to check.
+// @returns {bool} Whether the name is a rule.
+func IsRule(name string) bool {
+	return shared.ContainsString(Rules, name)
+}
+
+// `Content` lints the components of a content.
+// @param {Content} co - The content to lint.
+// @param {Options} options - The options of the linter.
+// @returns {[]Finding} The problems found, in the order of the components.
+func Content(co content.Content, options Options) []Finding {
+	l := linter{options: options, findings: make([]Finding, 0)}
+
+	l.component(co.RootComponent, "rootComponent")
+	if !l.heading && l.firstMarkdown != "" {
+		l.report(RuleMissingTopHeading, l.firstMarkdown, "the statement has no top-level heading")
+	}
+
+	return l.findings
+}
+
+// `linter` holds the state of a lint pass.
+// @property {string} firstMarkdown - The path of the first markdown component, which holds the statement.
+// @property {bool} heading - Whether the first markdown component has a top-level heading.
+type linter struct {
+	options       Options
+	findings      []Finding
+	firstMarkdown string
+	heading       bool
+}
+
+func (l *linter) report(rule string, path string, format string, args ...interface{}) {
+	if shared.ContainsString(l.options.Ignore, rule) {
+		return
+	}
+
+	l.findings = append(l.findings, Finding{
+		Rule:    rule,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) component(component content.Component, path string) {
+	switch component.Type {
+	case content.ComponentTypeContainer:
+		for i, child := range component.Data.Components {
+			l.component(child, fmt.Sprintf("%s.data.components[%d]", path, i))
+		}
+	case content.ComponentTypeMarkdown:
+		l.markdown(component.Data.Markdown, path)
+	case content.ComponentTypeEditor:
+		l.editor(component.Data, path)
+	}
+}
+
+var (
+	markdownLink    = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	markdownHeading = regexp.MustCompile(`(?m)^#[ \t]+\S`)
+)
+
+func (l *linter) markdown(markdown string, path string) {
+	if strings.TrimSpace(markdown) == "" {
+		l.report(RuleEmptyMarkdown, path, "the markdown is empty")
+	}
+
+	if l.firstMarkdown == "" {
+		l.firstMarkdown = path
+		l.heading = markdownHeading.MatchString(markdown)
+	}
+
+	for _, match := range markdownLink.FindAllStringSubmatch(markdown, -1) {
+		target := match[1]
+		if !isRelativeLink(target) {
+			continue
+		}
+
+		file := strings.SplitN(strings.SplitN(target, "#", 2)[0], "?", 2)[0]
+		if unescaped, err := url.PathUnescape(file); err == nil {
+			file = unescaped
+		}
+
+		// Without an exercise package, e.g. for a container block or definition_json, there is nothing to resolve
+		// the links from, they may point to files hosted next to the published content.
+		if l.options.BaseDir == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(l.options.BaseDir, filepath.FromSlash(file))); err != nil {
+			l.report(RuleBrokenRelativeLink, path+".data.markdown", "the relative link %q points to a missing file", target)
+		}
+	}
+}
+
+func (l *linter) editor(data content.ComponentData, path string) {
+	if len(data.Validators) == 0 {
+		l.report(RuleEditorWithoutValidators, path, "the editor has no validator, the exercise cannot be validated")
+	} else {
+		hidden := 0
+		for _, validator := range data.Validators {
+			if validator.IsHidden {
+				hidden++
+			}
+		}
+		if hidden == len(data.Validators) {
+			l.report(RuleEditorAllValidatorsHidden, path, "every validator of the editor is hidden, the user has no example to test against")
+		}
+	}
+
+	seen := make(map[string]int)
+	for i, validator := range data.Validators {
+		key := fmt.Sprintf("%q/%q", validator.Input.Stdin, validator.Output.Stdout)
+		if first, ok := seen[key]; ok {
+			l.report(RuleDuplicateValidators, fmt.Sprintf("%s.data.validators[%d]", path, i), "the validator duplicates validators[%d]", first)
+			continue
+		}
+		seen[key] = i
+	}
+
+	for i, language := range data.EditorSettings.Languages {
+		if strings.TrimSpace(language.DefaultCode) == "" {
+			l.report(RuleLanguageWithoutDefaultCode, fmt.Sprintf("%s.data.editorSettings.languages[%d]", path, i), "the language %s has no default code", language.Language)
+		}
+	}
+}
+
+// `isRelativeLink` tells whether a link target is a path relative to the markdown,
+// anchors, absolute paths and URLs with a scheme are not.
+func isRelativeLink(target string) bool {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") {
+		return false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return true
+	}
+
+	return u.Scheme == "" && u.Host == ""
+}
@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"polycode-provider/client/models/content"
+)
+
+func exercise(markdown string, editor content.ComponentData) content.Content {
+	return content.Content{
+		RootComponent: content.Component{
+			Type:        content.ComponentTypeContainer,
+			Orientation: "horizontal",
+			Data: content.ComponentData{
+				Components: []content.Component{
+					{Type: content.ComponentTypeMarkdown, Data: content.ComponentData{Markdown: markdown}},
+					{Type: content.ComponentTypeEditor, Data: editor},
+				},
+			},
+		},
+	}
+}
+
+func rules(findings []Finding) map[string]int {
+	result := make(map[string]int)
+	for _, finding := range findings {
+		result[finding.Rule]++
+	}
+
+	return result
+}
+
+func TestContent(t *testing.T) {
+	python := content.EditorSettings{Languages: []content.Language{{Language: "PYTHON", DefaultCode: "print()"}}}
+	validator := content.Validator{Input: content.ValidatorInput{Stdin: []string{"1"}}, Output: content.ValidatorOutput{Stdout: []string{"2"}}}
+
+	clean := exercise("# Title\n\nSee [the docs](https://example.com) and [below](#usage).", content.ComponentData{
+		EditorSettings: python,
+		Validators:     []content.Validator{validator},
+	})
+	findings := Content(clean, Options{})
+	if len(findings) != 0 {
+		t.Errorf("Expected no finding, got %v", findings)
+	}
+
+	hidden := validator
+	hidden.IsHidden = true
+	broken := exercise("Statement with ![an image](images/graph.png)", content.ComponentData{
+		EditorSettings: content.EditorSettings{Languages: []content.Language{{Language: "RUST"}}},
+		Validators:     []content.Validator{hidden, hidden},
+	})
+	found := rules(Content(broken, Options{BaseDir: t.TempDir()}))
+	for _, rule := range []string{RuleMissingTopHeading, RuleBrokenRelativeLink, RuleEditorAllValidatorsHidden, RuleDuplicateValidators, RuleLanguageWithoutDefaultCode} {
+		if found[rule] != 1 {
+			t.Errorf("Expected one %s finding, got %d", rule, found[rule])
+		}
+	}
+
+	found = rules(Content(broken, Options{}))
+	if found[RuleBrokenRelativeLink] != 0 {
+		t.Errorf("Relative links must not be checked without a base directory, got %v", found)
+	}
+
+	empty := exercise(" \n", content.ComponentData{EditorSettings: python})
+	found = rules(Content(empty, Options{Ignore: []string{RuleMissingTopHeading}}))
+	if found[RuleEmptyMarkdown] != 1 || found[RuleEditorWithoutValidators] != 1 {
+		t.Errorf("Expected empty markdown and editor without validators findings, got %v", found)
+	}
+	if found[RuleMissingTopHeading] != 0 {
+		t.Errorf("Ignored rules must not be reported, got %v", found)
+	}
+}
+
+func TestContentRelativeLinks(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "images"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "images", "graph.png"), []byte{}, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	co := exercise("# Title\n\n![graph](images/graph.png) [missing](images/missing.png#top)", content.ComponentData{
+		EditorSettings: content.EditorSettings{Languages: []content.Language{{Language: "NODE", DefaultCode: "//"}}},
+		Validators:     []content.Validator{{}},
+	})
+
+	findings := Content(co, Options{BaseDir: dir})
+	if len(findings) != 1 || findings[0].Rule != RuleBrokenRelativeLink || findings[0].Path != "rootComponent.data.components[0].data.markdown" {
+		t.Errorf("Expected only the missing image to be reported, got %v", findings)
+	}
+}
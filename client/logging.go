@@ -0,0 +1,162 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"polycode-provider/client/shared"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// `RequestIDHeader` is the header identifying a request in the logs of the provider and of the API.
+const RequestIDHeader = "X-Request-Id"
+
+// `maxLoggedBodySize` is the number of bytes of a request or response body written to the logs.
+const maxLoggedBodySize = 4096
+
+// `redacted` replaces the secrets in the logs.
+const redacted = "[REDACTED]"
+
+// `redactedHeaders` are the headers whose value is never logged.
+var redactedHeaders = []string{"Authorization"}
+
+// `redactedFields` are the JSON fields whose value is never logged: the password of `auth.LoginRequest`
+// and the access token of `auth.LoginResponse`.
+var redactedFields = []string{"secret", "accessToken"}
+
+// `logContext` returns the context the request is logged with: the context of the request when it has one,
+// otherwise the `LogContext` of the client.
+func (client *Client) logContext(req *http.Request) context.Context {
+	ctx := req.Context()
+	if ctx == context.Background() && client.LogContext != nil {
+		return client.LogContext
+	}
+
+	return ctx
+}
+
+// `logRequest` logs a request before it is sent, its headers and body are only logged at TRACE level.
+// @param ctx - The context holding the logger.
+// @param {http.Request} req - The request to log, it must carry a request ID.
+func logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"request_id": req.Header.Get(RequestIDHeader),
+	}
+	tflog.Debug(ctx, "Sending API request", fields)
+
+	fields["headers"] = redactHeaders(req.Header)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			raw, _ := io.ReadAll(body)
+			body.Close()
+			fields["body"] = redactBody(raw)
+		}
+	}
+	tflog.Trace(ctx, "API request", fields)
+}
+
+// `logResponse` logs the response of a request, its headers and body are only logged at TRACE level.
+// @param ctx - The context holding the logger.
+// @param {http.Request} req - The request that was sent.
+// @param {http.Response} res - The response, nil if the request failed.
+// @param {[]byte} body - The response body.
+// @param {time.Duration} duration - How long the request took.
+// @param {error} err - The error if the request failed.
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, duration time.Duration, err error) {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"request_id":  req.Header.Get(RequestIDHeader),
+		"duration_ms": duration.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+		return
+	}
+
+	fields["status"] = res.StatusCode
+	tflog.Debug(ctx, "Received API response", fields)
+
+	fields["headers"] = redactHeaders(res.Header)
+	fields["body"] = redactBody(body)
+	tflog.Trace(ctx, "API response", fields)
+}
+
+// `redactHeaders` flattens headers for the logs, hiding the value of the `redactedHeaders`.
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		result[name] = strings.Join(values, ", ")
+	}
+
+	for _, name := range redactedHeaders {
+		if headers.Get(name) != "" {
+			result[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+
+	return result
+}
+
+// `redactBody` formats a body for the logs, hiding the value of the `redactedFields` when it is JSON and
+// truncating it to `maxLoggedBodySize` bytes.
+func redactBody(body []byte) string {
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err == nil {
+		redactValue(value)
+		if raw, err := json.Marshal(value); err == nil {
+			body = raw
+		}
+	}
+
+	if len(body) > maxLoggedBodySize {
+		return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxLoggedBodySize], len(body)-maxLoggedBodySize)
+	}
+
+	return string(body)
+}
+
+// `redactValue` replaces the value of the `redactedFields` in a decoded JSON value, at any depth.
+func redactValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, element := range v {
+			if shared.ContainsString(redactedFields, key) {
+				v[key] = redacted
+				continue
+			}
+			redactValue(element)
+		}
+	case []interface{}:
+		for _, element := range v {
+			redactValue(element)
+		}
+	}
+}
+
+// `newRequestID` generates a random ID for a request.
+func newRequestID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"polycode-provider/client/models/auth"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(RequestIDHeader) == "" {
+			t.Errorf("Expected the request to carry a request ID")
+		}
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"accessToken":"token-value"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	c := &Client{
+		Host:       server.URL,
+		HTTPClient: server.Client(),
+		Auth:       auth.Credentials{Username: "admin", Password: "password-value"},
+		LogContext: tflogtest.RootLogger(context.Background(), &output),
+	}
+
+	token, err := c.Login()
+	if err != nil {
+		t.Fatalf("Error logging in: %s", err)
+	}
+	if *token != "token-value" {
+		t.Errorf("Expected the response not to be redacted, got %s", *token)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Error decoding logs: %s", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 log entries, got %d", len(entries))
+	}
+
+	response := entries[2]
+	if response["status"] != float64(200) || response["path"] != "/auth/token" || response["request_id"] == "" {
+		t.Errorf("Unexpected response log: %v", response)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"password-value", "token-value", "Bearer"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Expected %q to be redacted from the logs", secret)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := redactBody([]byte(`{"identity":"admin","secret":"12345678","nested":[{"accessToken":"abc"}]}`))
+	if body != `{"identity":"admin","nested":[{"accessToken":"[REDACTED]"}],"secret":"[REDACTED]"}` {
+		t.Errorf("Unexpected redacted body: %s", body)
+	}
+
+	body = redactBody([]byte(strings.Repeat("a", maxLoggedBodySize+10)))
+	if !strings.HasSuffix(body, "... (10 bytes truncated)") || len(body) != maxLoggedBodySize+len("... (10 bytes truncated)") {
+		t.Errorf("Expected the body to be truncated, got %d bytes", len(body))
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// `Metadata` is the metadata of the envelope of the API responses.
+// @property {*Pagination} Pagination - The pagination of a list, nil if the response is not a list.
+// @property {[]Warning} Warnings - The warnings about the request.
+// @property {[]Deprecation} Deprecations - The deprecated features used by the request.
+type Metadata struct {
+	Pagination   *Pagination   `json:"pagination,omitempty"`
+	Warnings     []Warning     `json:"warnings,omitempty"`
+	Deprecations []Deprecation `json:"deprecations,omitempty"`
+}
+
+// `Pagination` is the position of a page in a list.
+// @property {int} Page - The number of the page, starting from 1.
+// @property {int} PerPage - The number of elements per page.
+// @property {int} Total - The number of elements in the list.
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+	Total   int `json:"total"`
+}
+
+// `Warning` is a warning returned by the API about a successful request.
+// @property {string} Code - The code of the warning.
+// @property {string} Message - The description of the warning.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// `Deprecation` is a deprecated feature used by a request.
+// @property {string} Field - The deprecated field, empty if the whole endpoint is deprecated.
+// @property {string} Message - The description of the deprecation, usually with what to use instead.
+// @property {string} Sunset - When the feature will be removed, empty if not planned.
+type Deprecation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Sunset  string `json:"sunset"`
+}
+
+// `Notice` is a warning of the API or a deprecation that the user of the client should be told about.
+// @property {string} Summary - A short description of the notice.
+// @property {string} Detail - The details of the notice, with the request it is about.
+type Notice struct {
+	Summary string
+	Detail  string
+}
+
+// `Notices` collects the notices of the responses of a client, see `Client.Notices`.
+// It is safe to use from concurrent requests.
+type Notices struct {
+	mu      sync.Mutex
+	notices []Notice
+}
+
+// `add` adds a notice, unless it was already collected.
+func (n *Notices) add(notice Notice) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, existing := range n.notices {
+		if existing == notice {
+			return
+		}
+	}
+
+	n.notices = append(n.notices, notice)
+}
+
+// `List` returns the collected notices, in the order they were received.
+// @returns {[]Notice} - The notices.
+func (n *Notices) List() []Notice {
+	if n == nil {
+		return []Notice{}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]Notice{}, n.notices...)
+}
+
+// `recordMetadata` logs the warnings and deprecations of a response and adds them to the notices of the client.
+// They are read from the metadata of the envelope and from the `Deprecation` and `Sunset` headers.
+// @param {http.Request} req - The request that was sent.
+// @param {[]byte} body - The response body.
+// @param {http.Header} headers - The response headers.
+func (client *Client) recordMetadata(req *http.Request, body []byte, headers http.Header) {
+	envelope := struct {
+		Metadata Metadata `json:"metadata"`
+	}{}
+	// Not every response is an envelope, e.g. 204 No Content, their metadata is empty.
+	_ = json.Unmarshal(body, &envelope)
+
+	notices := metadataNotices(fmt.Sprintf("%s %s", req.Method, req.URL.Path), envelope.Metadata, headers)
+
+	for _, notice := range notices {
+		tflog.Warn(client.logContext(req), notice.Summary, map[string]interface{}{
+			"detail":     notice.Detail,
+			"request_id": req.Header.Get(RequestIDHeader),
+		})
+		client.Notices.add(notice)
+	}
+}
+
+// `metadataNotices` turns the warnings and deprecations of a response into notices.
+// @param {string} request - The method and path of the request, e.g. `GET /item/<id>`.
+// @param {Metadata} metadata - The metadata of the response.
+// @param {http.Header} headers - The response headers.
+// @returns {[]Notice} - The notices.
+func metadataNotices(request string, metadata Metadata, headers http.Header) []Notice {
+	result := make([]Notice, 0)
+
+	for _, warning := range metadata.Warnings {
+		detail := fmt.Sprintf("%s: %s", request, warning.Message)
+		if warning.Code != "" {
+			detail = fmt.Sprintf("%s: %s (%s)", request, warning.Message, warning.Code)
+		}
+
+		result = append(result, Notice{
+			Summary: "Polycode API warning",
+			Detail:  detail,
+		})
+	}
+
+	for _, deprecation := range metadata.Deprecations {
+		subject := request
+		if deprecation.Field != "" {
+			subject = fmt.Sprintf("%s, field %s", request, deprecation.Field)
+		}
+
+		detail := fmt.Sprintf("%s is deprecated: %s", subject, deprecation.Message)
+		if deprecation.Sunset != "" {
+			detail = fmt.Sprintf("%s (removed on %s)", detail, deprecation.Sunset)
+		}
+
+		result = append(result, Notice{
+			Summary: "Deprecated Polycode API feature",
+			Detail:  detail,
+		})
+	}
+
+	deprecated := headers.Get("Deprecation")
+	sunset := headers.Get("Sunset")
+	if (deprecated != "" && deprecated != "false") || sunset != "" {
+		detail := fmt.Sprintf("%s is deprecated.", request)
+		if sunset != "" {
+			detail = fmt.Sprintf("%s is deprecated and will be removed on %s.", request, sunset)
+		}
+
+		result = append(result, Notice{
+			Summary: "Deprecated Polycode API endpoint",
+			Detail:  detail,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseNotices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Sat, 31 Dec 2026 23:59:59 GMT")
+		_, _ = w.Write([]byte(`{
+			"metadata": {
+				"warnings": [{"code": "cost_ignored", "message": "The cost of the hint is ignored"}],
+				"deprecations": [{"field": "data.text", "message": "Use data.markdown instead", "sunset": "2026-12-31"}]
+			},
+			"data": {"id": "i1", "type": "hint", "data": {"text": "Use print"}, "cost": 10}
+		}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), Cache: NewCache(time.Minute), Notices: &Notices{}}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetItem("i1")
+		if err != nil {
+			t.Fatalf("Error getting item: %s", err)
+		}
+	}
+
+	notices := c.Notices.List()
+	if len(notices) != 3 {
+		t.Fatalf("Expected 3 notices read once, got %v", notices)
+	}
+
+	expected := []string{
+		"GET /item/i1: The cost of the hint is ignored (cost_ignored)",
+		"GET /item/i1, field data.text is deprecated: Use data.markdown instead (removed on 2026-12-31)",
+		"GET /item/i1 is deprecated and will be removed on Sat, 31 Dec 2026 23:59:59 GMT.",
+	}
+	for i, notice := range notices {
+		if notice.Detail != expected[i] {
+			t.Errorf("Expected notice %d to be %q, got %q", i, expected[i], notice.Detail)
+		}
+	}
+}
+
+func TestResponseWithoutNotices(t *testing.T) {
+	notices := metadataNotices("DELETE /item/i1", Metadata{}, http.Header{"Deprecation": []string{"false"}})
+	if len(notices) != 0 {
+		t.Errorf("Expected no notices, got %v", notices)
+	}
+
+	var disabled *Notices
+	disabled.add(Notice{Summary: "ignored"})
+	if len(disabled.List()) != 0 {
+		t.Errorf("Expected a nil collector to be disabled")
+	}
+}
@@ -0,0 +1,197 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"polycode-provider/client/shared"
+)
+
+// Component types available in a content.
+const (
+	ComponentTypeMarkdown  = "markdown"
+	ComponentTypeEditor    = "editor"
+	ComponentTypeContainer = "container"
+)
+
+// `Languages` are the languages available in an editor component.
+var Languages = []string{"PYTHON", "NODE", "JAVA", "RUST"}
+
+// `ParseDefinition` reads a root component definition, in the JSON shape of `CreateContentRequest.RootComponent`.
+// The definition is checked against the component model, unknown properties are rejected.
+// @param {string} definition - The JSON definition of the root component.
+// @returns {Component} - The root component, without IDs.
+// @returns {error} - An error if the definition is not valid JSON or does not match the component model.
+func ParseDefinition(definition string) (*Component, error) {
+	decoder := json.NewDecoder(strings.NewReader(definition))
+	decoder.DisallowUnknownFields()
+
+	request := CreateComponentRequest{}
+	err := decoder.Decode(&request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid definition: unexpected data after the root component")
+	}
+
+	if request.Type != ComponentTypeContainer {
+		return nil, fmt.Errorf("type: the root component must be a container, got %q", request.Type)
+	}
+	err = request.Validate("")
+	if err != nil {
+		return nil, err
+	}
+
+	component := request.IntoComponent()
+
+	return &component, nil
+}
+
+// `NormalizeDefinition` formats a root component definition in its canonical form, so that definitions
+// differing only by formatting, property order or omitted default values are equal.
+// @param {string} definition - The JSON definition of the root component.
+// @returns {string} - The canonical JSON definition.
+// @returns {error} - An error if the definition is not valid.
+func NormalizeDefinition(definition string) (string, error) {
+	component, err := ParseDefinition(definition)
+	if err != nil {
+		return "", err
+	}
+
+	result := bytes.Buffer{}
+	encoder := json.NewEncoder(&result)
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(component.IntoCreateComponentRequest())
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(result.String(), "\n"), nil
+}
+
+// `Validate` checks that the request matches the component model: the type is known and only the
+// properties of the type are set.
+// @param {string} path - The path of the component in the definition, used in the error messages.
+// @returns {error} - An error naming the path of the first invalid property.
+func (request *CreateComponentRequest) Validate(path string) error {
+	data := request.Data
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+
+	switch request.Type {
+	case ComponentTypeContainer:
+		if data.Markdown != nil || data.Items != nil || data.Validators != nil || data.EditorSettings != nil {
+			return fmt.Errorf("%sdata: a container only holds components and orientation", prefix)
+		}
+		if data.Orientation == nil || (*data.Orientation != "horizontal" && *data.Orientation != "vertical") {
+			return fmt.Errorf("%sdata.orientation: must be horizontal or vertical", prefix)
+		}
+		if data.Components == nil || len(*data.Components) == 0 {
+			return fmt.Errorf("%sdata.components: a container must hold at least one component", prefix)
+		}
+
+		for i, component := range *data.Components {
+			err := component.Validate(fmt.Sprintf("%sdata.components[%d]", prefix, i))
+			if err != nil {
+				return err
+			}
+		}
+	case ComponentTypeMarkdown:
+		if data.Components != nil || data.Items != nil || data.Validators != nil || data.EditorSettings != nil || data.Orientation != nil {
+			return fmt.Errorf("%sdata: a markdown only holds markdown", prefix)
+		}
+		if data.Markdown == nil {
+			return fmt.Errorf("%sdata.markdown: is required", prefix)
+		}
+	case ComponentTypeEditor:
+		if data.Components != nil || data.Markdown != nil || data.Orientation != nil {
+			return fmt.Errorf("%sdata: an editor only holds editorSettings, validators and items", prefix)
+		}
+		if data.EditorSettings == nil || len(data.EditorSettings.Languages) == 0 {
+			return fmt.Errorf("%sdata.editorSettings.languages: an editor must hold at least one language", prefix)
+		}
+
+		for i, language := range data.EditorSettings.Languages {
+			if !IsLanguage(language.Language) {
+				return fmt.Errorf("%sdata.editorSettings.languages[%d].language: must be one of %s, got %q", prefix, i, strings.Join(Languages, ", "), language.Language)
+			}
+		}
+		if data.Items != nil {
+			for i, item := range *data.Items {
+				if item == "" {
+					return fmt.Errorf("%sdata.items[%d]: must not be empty", prefix, i)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("%stype: must be one of %s, %s or %s, got %q", prefix, ComponentTypeMarkdown, ComponentTypeEditor, ComponentTypeContainer, request.Type)
+	}
+
+	return nil
+}
+
+// `IntoComponent` converts the request into a `Component`, without IDs.
+// @returns {Component} The converted component.
+func (request *CreateComponentRequest) IntoComponent() Component {
+	data := request.Data
+
+	result := Component{
+		Type: request.Type,
+	}
+
+	if data.Orientation != nil {
+		result.Orientation = *data.Orientation
+	}
+	if data.Markdown != nil {
+		result.Data.Markdown = *data.Markdown
+	}
+	if data.Components != nil {
+		result.Data.Components = make([]Component, 0)
+		for _, component := range *data.Components {
+			result.Data.Components = append(result.Data.Components, component.IntoComponent())
+		}
+	}
+	if data.Items != nil {
+		result.Data.Items = make([]ItemIdentifier, 0)
+		for _, item := range *data.Items {
+			result.Data.Items = append(result.Data.Items, ItemIdentifier{ID: item})
+		}
+	}
+	if data.Validators != nil {
+		result.Data.Validators = make([]Validator, 0)
+		for _, validator := range *data.Validators {
+			result.Data.Validators = append(result.Data.Validators, Validator{
+				IsHidden: validator.IsHidden,
+				Input:    ValidatorInput{Stdin: nonNilStrings(validator.Input.Stdin)},
+				Output:   ValidatorOutput{Stdout: nonNilStrings(validator.Expected.Stdout)},
+			})
+		}
+	}
+	if data.EditorSettings != nil {
+		result.Data.EditorSettings.Languages = make([]Language, 0)
+		for _, language := range data.EditorSettings.Languages {
+			result.Data.EditorSettings.Languages = append(result.Data.EditorSettings.Languages, Language(language))
+		}
+	}
+
+	return result
+}
+
+func nonNilStrings(slice []string) []string {
+	result := make([]string, 0)
+
+	return append(result, slice...)
+}
+
+// `IsLanguage` tells whether a language is one of the `Languages` available in an editor component.
+// @param {string} language - The language, e.g. `PYTHON`.
+// @returns {bool} - Whether the language is available.
+func IsLanguage(language string) bool {
+	return shared.ContainsString(Languages, language)
+}
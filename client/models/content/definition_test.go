@@ -0,0 +1,65 @@
+package content
+
+import (
+	"testing"
+)
+
+func TestNormalizeDefinition(t *testing.T) {
+	compact := `{"type":"container","data":{"orientation":"vertical","components":[{"type":"markdown","data":{"markdown":"# Title"}},{"type":"editor","data":{"editorSettings":{"languages":[{"language":"PYTHON","defaultCode":"","version":""}]},"validators":[{"isHidden":false,"input":{"stdin":[]},"expected":{"stdout":["ok"]}}],"items":[]}}]}}`
+	formatted := `{
+		"data": {
+			"components": [
+				{"data": {"markdown": "# Title"}, "type": "markdown"},
+				{
+					"type": "editor",
+					"data": {
+						"items": [],
+						"validators": [{"expected": {"stdout": ["ok"]}, "input": {"stdin": []}}],
+						"editorSettings": {"languages": [{"language": "PYTHON"}]}
+					}
+				}
+			],
+			"orientation": "vertical"
+		},
+		"type": "container"
+	}`
+
+	left, err := NormalizeDefinition(compact)
+	if err != nil {
+		t.Fatalf("Error normalizing compact definition: %s", err)
+	}
+	right, err := NormalizeDefinition(formatted)
+	if err != nil {
+		t.Fatalf("Error normalizing formatted definition: %s", err)
+	}
+	if left != right {
+		t.Errorf("Definitions differing only by formatting must be equal once normalized:\n%s\n%s", left, right)
+	}
+
+	component, err := ParseDefinition(formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(component.Data.Components) != 2 || component.Data.Components[1].Data.Validators[0].Output.Stdout[0] != "ok" {
+		t.Errorf("Unexpected component: %+v", component)
+	}
+}
+
+func TestParseDefinitionInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not json":           `{"type":`,
+		"root not container": `{"type":"markdown","data":{"markdown":"# Title"}}`,
+		"unknown property":   `{"type":"container","data":{"orientation":"vertical","components":[{"type":"markdown","data":{"markdown":"a","colour":"red"}}]}}`,
+		"unknown type":       `{"type":"container","data":{"orientation":"vertical","components":[{"type":"video","data":{}}]}}`,
+		"bad orientation":    `{"type":"container","data":{"orientation":"diagonal","components":[{"type":"markdown","data":{"markdown":"a"}}]}}`,
+		"editor language":    `{"type":"container","data":{"orientation":"vertical","components":[{"type":"editor","data":{"editorSettings":{"languages":[{"language":"COBOL"}]}}}]}}`,
+		"markdown in editor": `{"type":"container","data":{"orientation":"vertical","components":[{"type":"editor","data":{"markdown":"a","editorSettings":{"languages":[{"language":"RUST"}]}}}]}}`,
+	}
+
+	for name, definition := range cases {
+		_, err := ParseDefinition(definition)
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -18,8 +18,19 @@ type CreateContentRequest struct {
 }
 
 // `CreateContentRequestData` is the data that will be used to create the content,
-// for now it is empty but it will be used in the future
-type CreateContentRequestData struct{}
+// this structure holds pointers because all the properties are optional. Like the lists, which are always sent,
+// the difficulty and the estimated minutes are sent as null when they are not set, so that an update clears them.
+// @property {*string} Difficulty - The difficulty of the content. Can be one of the following:
+// `easy`, `medium`, `hard`.
+// @property {*int64} EstimatedMinutes - The estimated time to complete the content, in minutes.
+// @property {*[]string} Topics - A list of topic tags of the content.
+// @property {*[]string} LearningObjectives - A list of learning objectives of the content.
+type CreateContentRequestData struct {
+	Difficulty         *string   `json:"difficulty"`
+	EstimatedMinutes   *int64    `json:"estimatedMinutes"`
+	Topics             *[]string `json:"topics,omitempty"`
+	LearningObjectives *[]string `json:"learningObjectives,omitempty"`
+}
 
 // `CreateComponentRequest` is the request body holding information about a component.
 // @property {string} Type - The type of component you want to create. Can be one of the following:
@@ -102,8 +113,10 @@ type UpdateContentRequest struct {
 }
 
 // `UpdateContentRequestData` is the data that will be used to update the content,
-// for now it is empty but it will be used in the future
-type UpdateContentRequestData struct{}
+// it holds the same optional properties as `CreateContentRequestData`.
+type UpdateContentRequestData struct {
+	CreateContentRequestData
+}
 
 // `UpdateComponentRequest` is the request body holding information about a component.
 // @property {string} ID - The ID of the component.
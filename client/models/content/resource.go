@@ -10,6 +10,8 @@ import "polycode-provider/client/shared"
 // @property {int64} Reward - The amount of points the user will receive for completing this content.
 // @property {Component} RootComponent - This is the root component of the content.
 // @property {ContentData} Data - This is the data of the content.
+// @property {string} ETag - The version of the content returned by the API, sent back on update to detect concurrent changes.
+// @property {Audit} Audit - The audit information of the content, only set when read from the API.
 type Content struct {
 	ID            string
 	Name          string
@@ -18,6 +20,8 @@ type Content struct {
 	Reward        int64
 	RootComponent Component
 	Data          ContentData
+	ETag          string
+	shared.Audit
 }
 
 // `IntoCreateContentRequest` converts the content into a `CreateContentRequest`.
@@ -28,18 +32,8 @@ func (content *Content) IntoCreateContentRequest() CreateContentRequest {
 		Description: content.Description,
 		Type:        content.Type,
 		Reward:      content.Reward,
-		RootComponent: CreateComponentRequest{
-			Type: content.RootComponent.Type,
-			Data: CreateComponentRequestData{
-				Components:     content.RootComponent.Data.IntoCreateComponentRequest(),
-				Markdown:       shared.ConvertNilString(content.RootComponent.Data.Markdown),
-				Items:          content.RootComponent.Data.FlattenItemIdentifiers(),
-				Validators:     content.RootComponent.Data.IntoCreateValidatorRequest(),
-				EditorSettings: content.RootComponent.Data.IntoCreateEditorSettingsRequest(),
-				Orientation:    shared.ConvertNilString(content.RootComponent.Orientation),
-			},
-		},
-		Data: CreateContentRequestData{},
+		RootComponent: content.RootComponent.IntoCreateComponentRequest(),
+		Data:          content.Data.IntoCreateContentRequestData(),
 	}
 }
 
@@ -69,13 +63,52 @@ func (content Content) IntoUpdateContentRequest() UpdateContentRequest {
 				},
 			},
 		},
-		Data: UpdateContentRequestData{},
+		Data: UpdateContentRequestData{
+			CreateContentRequestData: content.Data.IntoCreateContentRequestData(),
+		},
 	}
 }
 
+// Content difficulties available in Polycode.
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
 // `ContentData` is the data of the content.
-// for now it is empty but it will be used in the future
-type ContentData struct{}
+// @property {string} Difficulty - The difficulty of the content, see the `Difficulty*` constants.
+// @property {int64} EstimatedMinutes - The estimated time to complete the content, in minutes.
+// @property {[]string} Topics - A list of topic tags of the content.
+// @property {[]string} LearningObjectives - A list of learning objectives of the content.
+type ContentData struct {
+	Difficulty         string
+	EstimatedMinutes   int64
+	Topics             []string
+	LearningObjectives []string
+}
+
+// `IntoCreateContentRequestData` converts the content data into a `CreateContentRequestData`.
+// Lists are always sent so that emptying them is applied by the API.
+// @returns {CreateContentRequestData} The converted content data.
+func (data *ContentData) IntoCreateContentRequestData() CreateContentRequestData {
+	topics := make([]string, 0)
+	topics = append(topics, data.Topics...)
+	learningObjectives := make([]string, 0)
+	learningObjectives = append(learningObjectives, data.LearningObjectives...)
+
+	result := CreateContentRequestData{
+		Difficulty:         shared.ConvertNilString(data.Difficulty),
+		Topics:             &topics,
+		LearningObjectives: &learningObjectives,
+	}
+
+	if data.EstimatedMinutes != 0 {
+		result.EstimatedMinutes = &data.EstimatedMinutes
+	}
+
+	return result
+}
 
 // `Component` is a component of the content.
 // @property {string} ID - The ID of the component.
@@ -91,6 +124,46 @@ type Component struct {
 	Data        ComponentData
 }
 
+// `IntoCreateComponentRequest` converts the component into a `CreateComponentRequest`.
+// @returns {CreateComponentRequest} The converted component.
+func (component *Component) IntoCreateComponentRequest() CreateComponentRequest {
+	return CreateComponentRequest{
+		Type: component.Type,
+		Data: CreateComponentRequestData{
+			Components:     component.Data.IntoCreateComponentRequest(),
+			Markdown:       shared.ConvertNilString(component.Data.Markdown),
+			Items:          component.Data.FlattenItemIdentifiers(),
+			Validators:     component.Data.IntoCreateValidatorRequest(),
+			EditorSettings: component.Data.IntoCreateEditorSettingsRequest(),
+			Orientation:    shared.ConvertNilString(component.Orientation),
+		},
+	}
+}
+
+// `InheritIDs` copies the IDs of a previous version of the component into the component,
+// so that the API updates the existing components instead of replacing them.
+// Nested components and validators are matched by position, an ID is only copied when
+// the type of the component did not change.
+// @param {Component} previous - The previous version of the component.
+func (component *Component) InheritIDs(previous Component) {
+	if component.Type != previous.Type {
+		return
+	}
+
+	component.ID = previous.ID
+
+	for i := range component.Data.Components {
+		if i < len(previous.Data.Components) {
+			component.Data.Components[i].InheritIDs(previous.Data.Components[i])
+		}
+	}
+	for i := range component.Data.Validators {
+		if i < len(previous.Data.Validators) {
+			component.Data.Validators[i].ID = previous.Data.Validators[i].ID
+		}
+	}
+}
+
 // `ComponentData` is the data of the component.
 // @property {Component[]} Components - An array of nested components. This is only used if the type is `container`.
 // @property {string} Markdown - The markdown of the component. This is only used if the type is `markdown`.
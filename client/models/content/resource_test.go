@@ -0,0 +1,20 @@
+package content
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestClearContentData(t *testing.T) {
+	content := Content{Data: ContentData{Topics: []string{"loops"}}}
+
+	body, err := json.Marshal(content.IntoUpdateContentRequest().Data)
+	if err != nil {
+		t.Fatalf("Error marshaling content data: %s", err)
+	}
+
+	expected := `{"difficulty":null,"estimatedMinutes":null,"topics":["loops"],"learningObjectives":[]}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+}
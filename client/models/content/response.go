@@ -9,15 +9,17 @@ import "polycode-provider/client/shared"
 // @property {string} Type - The type of content. Only `exercise` is available at the moment.
 // @property {int64} Reward - The amount of points the user will receive for completing this content.
 // @property {GetComponentResponse} RootComponent - This is the root component of the content.
-// @property Data - This is the data of the content.
+// @property {GetContentResponseData} Data - This is the data of the content.
+// @property {AuditResponse} AuditResponse - The audit information of the content.
 type GetContentResponse struct {
-	ID            string               `json:"id"`
-	Name          string               `json:"name"`
-	Description   string               `json:"description"`
-	Type          string               `json:"type"`
-	Reward        int64                `json:"reward"`
-	RootComponent GetComponentResponse `json:"rootComponent"`
-	Data          struct{}             `json:"data"`
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Type          string                 `json:"type"`
+	Reward        int64                  `json:"reward"`
+	RootComponent GetComponentResponse   `json:"rootComponent"`
+	Data          GetContentResponseData `json:"data"`
+	shared.AuditResponse
 }
 
 // `IntoContent` converts the response body into a pointer of a `Content` struct.
@@ -41,10 +43,44 @@ func (cr *GetContentResponse) IntoContent() *Content {
 			},
 			Orientation: shared.ConvertNilStringPointer(cr.RootComponent.Data.Orientation),
 		},
-		Data: ContentData{},
+		Data:  cr.Data.IntoContentData(),
+		Audit: cr.IntoAudit(),
 	}
 }
 
+// `GetContentResponseData` is the data of the content,
+// this structure holds pointers because all the properties are optional.
+// @property {*string} Difficulty - The difficulty of the content.
+// @property {*int64} EstimatedMinutes - The estimated time to complete the content, in minutes.
+// @property {*[]string} Topics - A list of topic tags of the content.
+// @property {*[]string} LearningObjectives - A list of learning objectives of the content.
+type GetContentResponseData struct {
+	Difficulty         *string   `json:"difficulty"`
+	EstimatedMinutes   *int64    `json:"estimatedMinutes"`
+	Topics             *[]string `json:"topics"`
+	LearningObjectives *[]string `json:"learningObjectives"`
+}
+
+// `IntoContentData` converts the response data into a `ContentData` struct.
+// @returns {ContentData} The content data.
+func (rd *GetContentResponseData) IntoContentData() ContentData {
+	result := ContentData{
+		Difficulty:         shared.ConvertNilStringPointer(rd.Difficulty),
+		EstimatedMinutes:   shared.ConvertNilInt64Pointer(rd.EstimatedMinutes),
+		Topics:             make([]string, 0),
+		LearningObjectives: make([]string, 0),
+	}
+
+	if rd.Topics != nil {
+		result.Topics = append(result.Topics, *rd.Topics...)
+	}
+	if rd.LearningObjectives != nil {
+		result.LearningObjectives = append(result.LearningObjectives, *rd.LearningObjectives...)
+	}
+
+	return result
+}
+
 // `GetComponentResponse` is the response body holding information about a component.
 // @property {string} ID - The unique identifier for the component.
 // @property {string} Type - The type of component. Can be one of the following:
@@ -198,7 +234,7 @@ type GetLanguageResponse struct {
 // @property {string} Type - The type of content. Only `exercise` is available at the moment.
 // @property {int64} Reward - The amount of points the user will receive for completing this content.
 // @property {GetComponentResponse} RootComponent - This is the root component of the content.
-// @property Data - This is the data of the content.
+// @property {GetContentResponseData} Data - This is the data of the content.
 type CreateContentResponse struct {
 	GetContentResponse
 }
@@ -210,7 +246,7 @@ type CreateContentResponse struct {
 // @property {string} Type - The type of content. Only `exercise` is available at the moment.
 // @property {int64} Reward - The amount of points the user will receive for completing this content.
 // @property {GetComponentResponse} RootComponent - This is the root component of the content.
-// @property Data - This is the data of the content.
+// @property {GetContentResponseData} Data - This is the data of the content.
 type UpdateContentResponse struct {
 	GetContentResponse
 }
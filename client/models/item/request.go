@@ -1,7 +1,7 @@
 package item
 
 // `CreateItemRequest` is the request body for the create item endpoint.
-// @property {string} Type - The type of item you want to create. Only `hint` is available at the moment.
+// @property {string} Type - The type of item you want to create, see the `Type*` constants.
 // @property {CreateItemRequestData} Data - This is the data that will be stored in the item.
 // @property {int64} Cost - The cost of the item in the currency specified in the request.
 type CreateItemRequest struct {
@@ -10,16 +10,36 @@ type CreateItemRequest struct {
 	Cost int64                 `json:"cost"`
 }
 
-// `CreateItemRequestData` is the data that will be stored in the item.
-// @property Text - The text of the item.
+// `CreateItemRequestData` is the data that will be stored in the item,
+// this structure holds pointers because only the properties of the item type are sent.
+// @property {*string} Text - The text of the item (only if type = hint).
+// @property {*string} ContentID - The ID of the related content (only if type = solutionReveal or hiddenTestReveal).
+// @property {*string} Language - The language of the revealed solution (only if type = solutionReveal).
+// @property {*string} ValidatorID - The ID of the revealed validator (only if type = hiddenTestReveal).
+// @property {*int64} Minutes - The number of minutes added to the time limit (only if type = timeExtension).
+// @property {*int64} Count - The number of additional submissions granted (only if type = retryToken).
 type CreateItemRequestData struct {
-	Text *string `json:"text"`
+	Text        *string `json:"text,omitempty"`
+	ContentID   *string `json:"contentId,omitempty"`
+	Language    *string `json:"language,omitempty"`
+	ValidatorID *string `json:"validatorId,omitempty"`
+	Minutes     *int64  `json:"minutes,omitempty"`
+	Count       *int64  `json:"count,omitempty"`
 }
 
 // `UpdateItemRequest` is the request body for the update item endpoint.
-// @property {string} Type - The type of item you want to create.
-// @property {CreateItemRequestData} Data - This is the data that will be stored in the item.
-// @property {int64} Cost - The cost of the item in the currency specified in the request.
+// All properties are optional, only the ones that are set are updated.
+// @property {*string} Type - The type of the item, the data must be sent along with it.
+// @property {*CreateItemRequestData} Data - This is the data that will be stored in the item.
+// @property {*int64} Cost - The cost of the item in the currency specified in the request.
 type UpdateItemRequest struct {
-	CreateItemRequest
+	Type *string                `json:"type,omitempty"`
+	Data *CreateItemRequestData `json:"data,omitempty"`
+	Cost *int64                 `json:"cost,omitempty"`
+}
+
+// `IsEmpty` tells whether the request does not update anything.
+// @returns {bool} Whether no property is set.
+func (r *UpdateItemRequest) IsEmpty() bool {
+	return r.Type == nil && r.Data == nil && r.Cost == nil
 }
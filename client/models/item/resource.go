@@ -1,17 +1,34 @@
 package item
 
-import "polycode-provider/client/shared"
+import (
+	"fmt"
+
+	"polycode-provider/client/shared"
+)
+
+// Item types available in the Polycode store.
+const (
+	TypeHint             = "hint"
+	TypeSolutionReveal   = "solutionReveal"
+	TypeHiddenTestReveal = "hiddenTestReveal"
+	TypeTimeExtension    = "timeExtension"
+	TypeRetryToken       = "retryToken"
+)
 
 // `Item` is a buyable item in Polycode.
 // @property {string} ID - The ID of the item.
-// @property {string} Type - The type of item. Only `hint` is available at the moment.
+// @property {string} Type - The type of item. It is the discriminant of `Data`, see the `Type*` constants.
 // @property {ItemData} Data - This is the data that is stored in the item.
 // @property {int64} Cost - The cost of the item in the store.
+// @property {string} ETag - The version of the item returned by the API, sent back on update to detect concurrent changes.
+// @property {Audit} Audit - The audit information of the item, only set when read from the API.
 type Item struct {
 	ID   string
 	Type string
 	Data ItemData
 	Cost int64
+	ETag string
+	shared.Audit
 }
 
 // `IntoCreateItemRequest` converts an `Item` into a `CreateItemRequest`.
@@ -19,9 +36,7 @@ type Item struct {
 func (i *Item) IntoCreateItemRequest() CreateItemRequest {
 	return CreateItemRequest{
 		Type: i.Type,
-		Data: CreateItemRequestData{
-			Text: shared.ConvertNilString(i.Data.Text),
-		},
+		Data: i.Data.IntoCreateItemRequestData(),
 		Cost: i.Cost,
 	}
 }
@@ -29,19 +44,131 @@ func (i *Item) IntoCreateItemRequest() CreateItemRequest {
 // `IntoUpdateItemRequest` converts an `Item` into a `UpdateItemRequest`.
 // @returns {UpdateItemRequest} The `UpdateItemRequest` that was created.
 func (i *Item) IntoUpdateItemRequest() UpdateItemRequest {
+	data := i.Data.IntoCreateItemRequestData()
+
 	return UpdateItemRequest{
-		CreateItemRequest: CreateItemRequest{
-			Type: i.Type,
-			Cost: i.Cost,
-			Data: CreateItemRequestData{
-				Text: shared.ConvertNilString(i.Data.Text),
-			},
-		},
+		Type: &i.Type,
+		Cost: &i.Cost,
+		Data: &data,
 	}
 }
 
+// `Validate` checks that the data of the item matches its type.
+// @returns {error} - An error if the data does not match the type.
+func (i *Item) Validate() error {
+	set := i.Data.Type()
+	if set == "" {
+		return fmt.Errorf("item of type %q has no data", i.Type)
+	}
+	if set != i.Type {
+		return fmt.Errorf("item of type %q holds %q data", i.Type, set)
+	}
+
+	return nil
+}
+
 // `ItemData` is the data that is stored in the item.
-// @property {string} Text - The text to display in the item.
+// It is a discriminated union: only the property matching the item type must be set.
+// @property {*HintData} Hint - The data of a `hint` item.
+// @property {*SolutionRevealData} SolutionReveal - The data of a `solutionReveal` item.
+// @property {*HiddenTestRevealData} HiddenTestReveal - The data of a `hiddenTestReveal` item.
+// @property {*TimeExtensionData} TimeExtension - The data of a `timeExtension` item.
+// @property {*RetryTokenData} RetryToken - The data of a `retryToken` item.
 type ItemData struct {
+	Hint             *HintData
+	SolutionReveal   *SolutionRevealData
+	HiddenTestReveal *HiddenTestRevealData
+	TimeExtension    *TimeExtensionData
+	RetryToken       *RetryTokenData
+}
+
+// `Type` returns the item type matching the data that is set.
+// @returns {string} The item type, empty if no data is set or if more than one is set.
+func (d *ItemData) Type() string {
+	result := ""
+	count := 0
+
+	if d.Hint != nil {
+		result = TypeHint
+		count++
+	}
+	if d.SolutionReveal != nil {
+		result = TypeSolutionReveal
+		count++
+	}
+	if d.HiddenTestReveal != nil {
+		result = TypeHiddenTestReveal
+		count++
+	}
+	if d.TimeExtension != nil {
+		result = TypeTimeExtension
+		count++
+	}
+	if d.RetryToken != nil {
+		result = TypeRetryToken
+		count++
+	}
+
+	if count != 1 {
+		return ""
+	}
+
+	return result
+}
+
+// `IntoCreateItemRequestData` converts an `ItemData` into a `CreateItemRequestData`.
+// @returns {CreateItemRequestData} The `CreateItemRequestData` that was created.
+func (d *ItemData) IntoCreateItemRequestData() CreateItemRequestData {
+	result := CreateItemRequestData{}
+
+	switch {
+	case d.Hint != nil:
+		result.Text = shared.ConvertNilString(d.Hint.Text)
+	case d.SolutionReveal != nil:
+		result.ContentID = shared.ConvertNilString(d.SolutionReveal.ContentID)
+		result.Language = shared.ConvertNilString(d.SolutionReveal.Language)
+	case d.HiddenTestReveal != nil:
+		result.ContentID = shared.ConvertNilString(d.HiddenTestReveal.ContentID)
+		result.ValidatorID = shared.ConvertNilString(d.HiddenTestReveal.ValidatorID)
+	case d.TimeExtension != nil:
+		result.Minutes = &d.TimeExtension.Minutes
+	case d.RetryToken != nil:
+		result.Count = &d.RetryToken.Count
+	}
+
+	return result
+}
+
+// `HintData` is the data of a `hint` item.
+// @property {string} Text - The text to display in the hint.
+type HintData struct {
 	Text string
 }
+
+// `SolutionRevealData` is the data of a `solutionReveal` item.
+// @property {string} ContentID - The ID of the content whose solution is revealed.
+// @property {string} Language - The language of the revealed solution, every language if empty.
+type SolutionRevealData struct {
+	ContentID string
+	Language  string
+}
+
+// `HiddenTestRevealData` is the data of a `hiddenTestReveal` item.
+// @property {string} ContentID - The ID of the content holding the hidden validator.
+// @property {string} ValidatorID - The ID of the hidden validator to reveal.
+type HiddenTestRevealData struct {
+	ContentID   string
+	ValidatorID string
+}
+
+// `TimeExtensionData` is the data of a `timeExtension` item.
+// @property {int64} Minutes - The number of minutes added to the time limit.
+type TimeExtensionData struct {
+	Minutes int64
+}
+
+// `RetryTokenData` is the data of a `retryToken` item.
+// @property {int64} Count - The number of additional submissions granted.
+type RetryTokenData struct {
+	Count int64
+}
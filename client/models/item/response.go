@@ -4,33 +4,77 @@ import "polycode-provider/client/shared"
 
 // `GetItemResponse` is the response body for the get item endpoint.
 // @property {string} ID - The ID of the item.
-// @property {string} Type - The type of the item. Only `hint` is available at the moment.
+// @property {string} Type - The type of the item, see the `Type*` constants.
 // @property {GetItemResponseData} Data - The data that is stored in the item.
 // @property {int64} Cost - The cost of the request.
+// @property {AuditResponse} AuditResponse - The audit information of the item.
 type GetItemResponse struct {
 	ID   string              `json:"id"`
 	Type string              `json:"type"`
 	Data GetItemResponseData `json:"data"`
 	Cost int64               `json:"cost"`
+	shared.AuditResponse
 }
 
 // `IntoItem` converts a `GetItemResponse` into a pointer of an `Item`.
 // @returns {Item} The `Item` that was created.
 func (i *GetItemResponse) IntoItem() *Item {
 	return &Item{
-		ID:   i.ID,
-		Type: i.Type,
-		Data: ItemData{
-			Text: shared.ConvertNilStringPointer(i.Data.Text),
-		},
-		Cost: i.Cost,
+		ID:    i.ID,
+		Type:  i.Type,
+		Data:  i.Data.IntoItemData(i.Type),
+		Cost:  i.Cost,
+		Audit: i.IntoAudit(),
 	}
 }
 
-// `GetItemResponseData` is the data that is stored in the item.
-// @property Text - The text of the item.
+// `GetItemResponseData` is the data that is stored in the item,
+// this structure holds pointers because only the properties of the item type are returned.
+// @property {*string} Text - The text of the item (only if type = hint).
+// @property {*string} ContentID - The ID of the related content (only if type = solutionReveal or hiddenTestReveal).
+// @property {*string} Language - The language of the revealed solution (only if type = solutionReveal).
+// @property {*string} ValidatorID - The ID of the revealed validator (only if type = hiddenTestReveal).
+// @property {*int64} Minutes - The number of minutes added to the time limit (only if type = timeExtension).
+// @property {*int64} Count - The number of additional submissions granted (only if type = retryToken).
 type GetItemResponseData struct {
-	Text *string `json:"text"`
+	Text        *string `json:"text"`
+	ContentID   *string `json:"contentId"`
+	Language    *string `json:"language"`
+	ValidatorID *string `json:"validatorId"`
+	Minutes     *int64  `json:"minutes"`
+	Count       *int64  `json:"count"`
+}
+
+// `IntoItemData` converts the response data into an `ItemData` using the item type as discriminant.
+// @param {string} itemType - The type of the item.
+// @returns {ItemData} The `ItemData` that was created, empty if the type is unknown.
+func (d *GetItemResponseData) IntoItemData(itemType string) ItemData {
+	switch itemType {
+	case TypeHint:
+		return ItemData{Hint: &HintData{
+			Text: shared.ConvertNilStringPointer(d.Text),
+		}}
+	case TypeSolutionReveal:
+		return ItemData{SolutionReveal: &SolutionRevealData{
+			ContentID: shared.ConvertNilStringPointer(d.ContentID),
+			Language:  shared.ConvertNilStringPointer(d.Language),
+		}}
+	case TypeHiddenTestReveal:
+		return ItemData{HiddenTestReveal: &HiddenTestRevealData{
+			ContentID:   shared.ConvertNilStringPointer(d.ContentID),
+			ValidatorID: shared.ConvertNilStringPointer(d.ValidatorID),
+		}}
+	case TypeTimeExtension:
+		return ItemData{TimeExtension: &TimeExtensionData{
+			Minutes: shared.ConvertNilInt64Pointer(d.Minutes),
+		}}
+	case TypeRetryToken:
+		return ItemData{RetryToken: &RetryTokenData{
+			Count: shared.ConvertNilInt64Pointer(d.Count),
+		}}
+	}
+
+	return ItemData{}
 }
 
 // `CreateItemResponse` is the response body for the create item endpoint.
@@ -22,11 +22,24 @@ type CreateModuleRequest struct {
 }
 
 // `CreateModuleRequestData` is the data that will be used to create the module,
-// for now it is empty but it will be used in the future
-type CreateModuleRequestData struct{}
+// this structure holds pointers because all the properties are optional.
+// @property {*string} Visibility - The visibility of the module. Can be one of the following:
+// `draft`, `published`, `archived`.
+// @property {*string} Difficulty - The difficulty of the module. Can be one of the following:
+// `easy`, `medium`, `hard`.
+// @property {*int64} EstimatedMinutes - The estimated time to complete the module, in minutes.
+// @property {*string} StartsAt - The RFC3339 date from which the module is available.
+// @property {*string} EndsAt - The RFC3339 date until which the module is available.
+type CreateModuleRequestData struct {
+	Visibility       *string `json:"visibility,omitempty"`
+	Difficulty       *string `json:"difficulty,omitempty"`
+	EstimatedMinutes *int64  `json:"estimatedMinutes,omitempty"`
+	StartsAt         *string `json:"startsAt,omitempty"`
+	EndsAt           *string `json:"endsAt,omitempty"`
+}
 
-// `UpdateModuleRequest` is the request body for creating a module.
-// All properties are optional.
+// `UpdateModuleRequest` is the request body for updating a module.
+// All properties are optional, only the ones that are set are updated.
 // @property {string} Name - The name of the module.
 // @property {string} Description - A description of the module
 // @property {string} Type - The type of module. This can be one of the following:
@@ -47,6 +60,25 @@ type UpdateModuleRequest struct {
 	Contents    *[]string                `json:"contents,omitempty"`
 }
 
+// `IsEmpty` tells whether the request does not update anything.
+// @returns {bool} Whether no property is set.
+func (r *UpdateModuleRequest) IsEmpty() bool {
+	return r.Name == nil && r.Description == nil && r.Type == nil && r.Reward == nil &&
+		r.Tags == nil && r.Data == nil && r.Modules == nil && r.Contents == nil
+}
+
 // `UpdateModuleRequestData` is the data that will be used to update the module,
-// for now it is empty but it will be used in the future
-type UpdateModuleRequestData struct{}
+// it holds the same properties as `CreateModuleRequestData`. Every property but the visibility is sent as null
+// when it is not set, so that it is cleared rather than left unchanged.
+// @property {*string} Visibility - The visibility of the module, left unchanged if not set.
+// @property {*string} Difficulty - The difficulty of the module.
+// @property {*int64} EstimatedMinutes - The estimated time to complete the module, in minutes.
+// @property {*string} StartsAt - The RFC3339 date from which the module is available.
+// @property {*string} EndsAt - The RFC3339 date until which the module is available.
+type UpdateModuleRequestData struct {
+	Visibility       *string `json:"visibility,omitempty"`
+	Difficulty       *string `json:"difficulty"`
+	EstimatedMinutes *int64  `json:"estimatedMinutes"`
+	StartsAt         *string `json:"startsAt"`
+	EndsAt           *string `json:"endsAt"`
+}
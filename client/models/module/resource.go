@@ -1,5 +1,27 @@
 package module
 
+import (
+	"time"
+
+	"polycode-provider/client/shared"
+)
+
+// Module types available in Polycode.
+const (
+	TypeChallenge     = "challenge"
+	TypePractice      = "practice"
+	TypeCertification = "certification"
+	TypeSubmodule     = "submodule"
+)
+
+// `CanBeNested` tells whether a module of the given type can be held by another module, whatever its type.
+// Only submodules can be nested, top level modules (challenge, practice, certification) are never children.
+// @param {string} moduleType - The type of the module.
+// @returns {bool} Whether the module can be held by another module.
+func CanBeNested(moduleType string) bool {
+	return moduleType == TypeSubmodule
+}
+
 // `Module` is a folder containing content and other modules.
 // @property {string} ID - The unique identifier for the module.
 // @property {string} Name - The name of the module.
@@ -13,6 +35,8 @@ package module
 // this module can be completed.
 // @property {[]ContentIdentifier} Contents - A list of ContentIdentifier objects. These are the
 // contents that are required to complete the module.
+// @property {string} ETag - The version of the module returned by the API, sent back on update to detect concurrent changes.
+// @property {Audit} Audit - The audit information of the module, only set when read from the API.
 type Module struct {
 	ID          string
 	Name        string
@@ -23,6 +47,8 @@ type Module struct {
 	Data        ModuleData
 	Modules     []ModuleIdentifier
 	Contents    []ContentIdentifier
+	ETag        string
+	shared.Audit
 }
 
 // `IntoCreateModuleRequest` converts a module into a create module request.
@@ -34,7 +60,7 @@ func (m *Module) IntoCreateModuleRequest() CreateModuleRequest {
 		Type:        m.Type,
 		Reward:      m.Reward,
 		Tags:        m.Tags,
-		Data:        CreateModuleRequestData{},
+		Data:        m.Data.IntoCreateModuleRequestData(),
 		Modules:     *m.FlattenModuleIdentifiers(),
 		Contents:    *m.FlattenContentIdentifiers(),
 	}
@@ -49,12 +75,36 @@ func (m *Module) IntoUpdateModuleRequest() UpdateModuleRequest {
 		Type:        &m.Type,
 		Reward:      &m.Reward,
 		Tags:        &m.Tags,
-		Data:        &UpdateModuleRequestData{},
+		Data:        m.Data.IntoUpdateModuleRequestData(),
 		Modules:     m.FlattenModuleIdentifiers(),
 		Contents:    m.FlattenContentIdentifiers(),
 	}
 }
 
+// `MergeTags` returns the tags followed by the default tags they do not already hold.
+// @param {[]string} tags - The tags of the module.
+// @param {[]string} defaultTags - The tags every module must carry.
+// @returns {[]string} The merged tags.
+func MergeTags(tags []string, defaultTags []string) []string {
+	result := make([]string, 0)
+
+	for _, tag := range append(append(make([]string, 0), tags...), defaultTags...) {
+		duplicate := false
+		for _, v := range result {
+			if v == tag {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
 // `FlattenModuleIdentifiers` flattens the module identifiers into a list of IDs.
 // @returns {[]string} The list of IDs.
 func (m *Module) FlattenModuleIdentifiers() *[]string {
@@ -87,9 +137,66 @@ func (m *Module) FlattenContentIdentifiers() *[]string {
 	return &result
 }
 
-// `ModuleData` is the data that the module will use to generate the content,
-// for now it is empty but it will be used in the future
-type ModuleData struct{}
+// Module visibilities available in Polycode.
+const (
+	VisibilityDraft     = "draft"
+	VisibilityPublished = "published"
+	VisibilityArchived  = "archived"
+)
+
+// Module difficulties available in Polycode.
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// `ModuleData` is the data that the module will use to generate the content.
+// @property {string} Visibility - The visibility of the module, see the `Visibility*` constants.
+// Empty to let the API choose.
+// @property {string} Difficulty - The difficulty of the module, see the `Difficulty*` constants.
+// @property {int64} EstimatedMinutes - The estimated time to complete the module, in minutes.
+// @property {*time.Time} StartsAt - The date from which the module is available, nil if it always was.
+// @property {*time.Time} EndsAt - The date until which the module is available, nil if it never ends.
+type ModuleData struct {
+	Visibility       string
+	Difficulty       string
+	EstimatedMinutes int64
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+}
+
+// `IntoCreateModuleRequestData` converts the module data into a create module request data.
+// @returns {CreateModuleRequestData} The create module request data.
+func (md *ModuleData) IntoCreateModuleRequestData() CreateModuleRequestData {
+	result := CreateModuleRequestData{
+		Visibility: shared.ConvertNilString(md.Visibility),
+		Difficulty: shared.ConvertNilString(md.Difficulty),
+		StartsAt:   shared.FormatNilTime(md.StartsAt),
+		EndsAt:     shared.FormatNilTime(md.EndsAt),
+	}
+
+	if md.EstimatedMinutes != 0 {
+		result.EstimatedMinutes = &md.EstimatedMinutes
+	}
+
+	return result
+}
+
+// `IntoUpdateModuleRequestData` converts the module data into an update module request data, clearing the
+// properties that are not set.
+// @returns {UpdateModuleRequestData} The update module request data.
+func (md *ModuleData) IntoUpdateModuleRequestData() *UpdateModuleRequestData {
+	data := md.IntoCreateModuleRequestData()
+
+	return &UpdateModuleRequestData{
+		Visibility:       data.Visibility,
+		Difficulty:       data.Difficulty,
+		EstimatedMinutes: data.EstimatedMinutes,
+		StartsAt:         data.StartsAt,
+		EndsAt:           data.EndsAt,
+	}
+}
 
 // `ModuleIdentifier` is a module identifier.
 // @property {string} ID - The unique identifier for the module.
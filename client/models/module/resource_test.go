@@ -0,0 +1,32 @@
+package module
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestClearModuleData(t *testing.T) {
+	startsAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := ModuleData{Difficulty: DifficultyEasy, StartsAt: &startsAt}
+
+	body, err := json.Marshal(data.IntoUpdateModuleRequestData())
+	if err != nil {
+		t.Fatalf("Error marshaling module data: %s", err)
+	}
+	expected := `{"difficulty":"easy","estimatedMinutes":null,"startsAt":"2026-01-01T00:00:00Z","endsAt":null}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+
+	// Removing starts_at from the configuration clears it.
+	data.StartsAt = nil
+	body, err = json.Marshal(data.IntoUpdateModuleRequestData())
+	if err != nil {
+		t.Fatalf("Error marshaling module data: %s", err)
+	}
+	expected = `{"difficulty":"easy","estimatedMinutes":null,"startsAt":null,"endsAt":null}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+}
@@ -1,5 +1,7 @@
 package module
 
+import "polycode-provider/client/shared"
+
 // `GetModuleResponse` is the response body for getting a module.
 // @property {string} ID - The ID of the module.
 // @property {string} Name - The name of the module
@@ -12,6 +14,7 @@ package module
 // content.
 // @property {[]string} Modules - A list of module IDs that are required to complete this module.
 // @property {[]string} Contents - A list of content IDs that are part of this module.
+// @property {AuditResponse} AuditResponse - The audit information of the module.
 type GetModuleResponse struct {
 	ID          string                               `json:"id"`
 	Name        string                               `json:"name"`
@@ -22,6 +25,7 @@ type GetModuleResponse struct {
 	Data        GetModuleResponseData                `json:"data"`
 	Modules     []GetModuleResponseModuleIdentifier  `json:"modules"`
 	Contents    []GetModuleResponseContentIdentifier `json:"contents"`
+	shared.AuditResponse
 }
 
 func (mr *GetModuleResponse) IntoModule() *Module {
@@ -32,9 +36,10 @@ func (mr *GetModuleResponse) IntoModule() *Module {
 		Type:        mr.Type,
 		Reward:      mr.Reward,
 		Tags:        mr.Tags,
-		Data:        ModuleData(mr.Data),
+		Data:        mr.Data.IntoModuleData(),
 		Modules:     mr.IntoModuleIdentifier(),
 		Contents:    mr.IntoContentIdentifier(),
+		Audit:       mr.IntoAudit(),
 	}
 }
 
@@ -67,8 +72,32 @@ func (mr *GetModuleResponse) IntoContentIdentifier() []ContentIdentifier {
 }
 
 // `GetModuleResponseData` is the data that the module will use to generate the content,
-// for now it is empty but it will be used in the future.
-type GetModuleResponseData struct{}
+// this structure holds pointers because all the properties are optional.
+// @property {*string} Visibility - The visibility of the module.
+// @property {*string} Difficulty - The difficulty of the module.
+// @property {*int64} EstimatedMinutes - The estimated time to complete the module, in minutes.
+// @property {*string} StartsAt - The RFC3339 date from which the module is available.
+// @property {*string} EndsAt - The RFC3339 date until which the module is available.
+type GetModuleResponseData struct {
+	Visibility       *string `json:"visibility"`
+	Difficulty       *string `json:"difficulty"`
+	EstimatedMinutes *int64  `json:"estimatedMinutes"`
+	StartsAt         *string `json:"startsAt"`
+	EndsAt           *string `json:"endsAt"`
+}
+
+// `IntoModuleData` converts the response data into a `ModuleData`,
+// dates that cannot be parsed as RFC3339 are ignored.
+// @returns {ModuleData} The `ModuleData`
+func (rd *GetModuleResponseData) IntoModuleData() ModuleData {
+	return ModuleData{
+		Visibility:       shared.ConvertNilStringPointer(rd.Visibility),
+		Difficulty:       shared.ConvertNilStringPointer(rd.Difficulty),
+		EstimatedMinutes: shared.ConvertNilInt64Pointer(rd.EstimatedMinutes),
+		StartsAt:         shared.ParseNilTimePointer(rd.StartsAt),
+		EndsAt:           shared.ParseNilTimePointer(rd.EndsAt),
+	}
+}
 
 // `GetModuleResponseModuleIdentifier` is the module identifier that is used in the response body
 // for getting a module.
@@ -99,9 +128,10 @@ func (mr *CreateModuleResponse) IntoModule() *Module {
 		Type:        mr.Type,
 		Reward:      mr.Reward,
 		Tags:        mr.Tags,
-		Data:        ModuleData(mr.Data),
+		Data:        mr.Data.IntoModuleData(),
 		Modules:     mr.IntoModuleIdentifier(),
 		Contents:    mr.IntoContentIdentifier(),
+		Audit:       mr.IntoAudit(),
 	}
 }
 
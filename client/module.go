@@ -9,7 +9,7 @@ import (
 )
 
 type GetModuleResponse struct {
-	Metadata struct{}                 `json:"metadata"`
+	Metadata Metadata                 `json:"metadata"`
 	Data     models.GetModuleResponse `json:"data"`
 }
 
@@ -27,7 +27,7 @@ func (c *Client) GetModule(ID string) (*models.Module, error) {
 		return nil, err
 	}
 
-	body, err := c.fetchAPI(req, nil)
+	body, headers, err := c.fetchCached(fmt.Sprintf("module/%s", ID), req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,11 +38,14 @@ func (c *Client) GetModule(ID string) (*models.Module, error) {
 		return nil, err
 	}
 
-	return moduleResponse.Data.IntoModule(), nil
+	result := moduleResponse.Data.IntoModule()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 type CreateModuleResponse struct {
-	Metadata struct{}                    `json:"metadata"`
+	Metadata Metadata                    `json:"metadata"`
 	Data     models.CreateModuleResponse `json:"data"`
 }
 
@@ -61,7 +64,7 @@ func (c *Client) CreateModule(module models.Module) (*models.Module, error) {
 		return nil, err
 	}
 
-	body, err = c.fetchAPI(req, nil)
+	body, headers, err := c.fetchAPIWithHeaders(req, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -72,25 +75,51 @@ func (c *Client) CreateModule(module models.Module) (*models.Module, error) {
 		return nil, err
 	}
 
-	return moduleResponse.Data.IntoModule(), nil
+	result := moduleResponse.Data.IntoModule()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
-// `UpdateModule` updates a module in the API.
+// `UpdateModule` updates every property of a module in the API.
 // @param {Module} module - The module to update.
 // @returns {Module} - The module that was updated.
 // @returns {error} - An error if there was a problem updating the module.
 func (c *Client) UpdateModule(module models.Module) (*models.Module, error) {
-	body, err := json.Marshal(module.IntoUpdateModuleRequest())
+	return c.PatchModule(module.ID, module.IntoUpdateModuleRequest(), module.ETag)
+}
+
+// `PatchModule` updates a module in the API, sending only the properties that are set in the request.
+// @param {string} ID - The ID of the module to update.
+// @param {UpdateModuleRequest} request - The properties to update.
+// @param {string} etag - The ETag of the module when it was last read, the update is rejected with
+// an `APIErrorPreconditionFailed` error if the module changed since. Empty to update unconditionally.
+// @returns {Module} - The module that was updated.
+// @returns {error} - An error if there was a problem updating the module.
+func (c *Client) PatchModule(ID string, request models.UpdateModuleRequest, etag string) (*models.Module, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("empty ID")
+	}
+
+	if request.IsEmpty() {
+		return c.GetModule(ID)
+	}
+
+	body, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/module/%s", c.Host, module.ID), bytes.NewBuffer(body))
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/module/%s", c.Host, ID), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
-	body, err = c.fetchAPI(req, nil)
+	body, headers, err := c.fetchAPIWithHeaders(req, nil)
+	c.Cache.Invalidate(fmt.Sprintf("module/%s", ID))
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +130,10 @@ func (c *Client) UpdateModule(module models.Module) (*models.Module, error) {
 		return nil, err
 	}
 
-	return moduleResponse.Data.IntoModule(), nil
+	result := moduleResponse.Data.IntoModule()
+	result.ETag = headers.Get("ETag")
+
+	return result, nil
 }
 
 // `DeleteModule` deletes a module from the API.
@@ -118,9 +150,38 @@ func (c *Client) DeleteModule(ID string) error {
 	}
 
 	_, err = c.fetchAPI(req, nil)
+	c.Cache.Invalidate(fmt.Sprintf("module/%s", ID))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// `ValidateModule` asks the API whether it would accept a module, without creating or updating it.
+// A module holding an ID is validated as an update of that module, otherwise as a new module.
+// @param {Module} module - The module to validate.
+// @returns {error} - An `APIErrorBadRequest` error holding the rules the module breaks, see `ValidationErrors`,
+// or an error if there was a problem validating the module.
+func (c *Client) ValidateModule(module models.Module) error {
+	url := fmt.Sprintf("%s/module/validate", c.Host)
+	var request interface{} = module.IntoCreateModuleRequest()
+	if module.ID != "" {
+		url = fmt.Sprintf("%s/module/%s/validate", c.Host, module.ID)
+		request = module.IntoUpdateModuleRequest()
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.fetchAPI(req, nil)
+
+	return err
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	models "polycode-provider/client/models/module"
+	"polycode-provider/client/shared"
+)
+
+// `moduleLocks` serializes the read-modify-write updates of a module, keyed by module ID.
+var moduleLocks sync.Map
+
+// `lockModule` locks the module with the given ID and returns the function unlocking it.
+func lockModule(ID string) func() {
+	lock, _ := moduleLocks.LoadOrStore(ID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+
+	return lock.(*sync.Mutex).Unlock
+}
+
+// `UpdateModuleMembers` atomically updates a module from its current version in the API.
+// The module is fetched, `update` computes the request from it and the request is sent,
+// while no other call to `UpdateModuleMembers` can update the same module.
+// @param {string} ID - The ID of the module to update.
+// @param update - The function computing the update request from the current module.
+// @returns {Module} - The module that was updated.
+// @returns {error} - An error if there was a problem updating the module.
+func (c *Client) UpdateModuleMembers(ID string, update func(current *models.Module) models.UpdateModuleRequest) (*models.Module, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("empty ID")
+	}
+
+	unlock := lockModule(ID)
+	defer unlock()
+
+	// The module is read again from the API, a cached version would be rejected by the ETag check
+	c.Cache.Invalidate(fmt.Sprintf("module/%s", ID))
+
+	current, err := c.GetModule(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PatchModule(ID, update(current), current.ETag)
+}
+
+// `AttachContent` adds a content to a module, leaving the other contents untouched.
+// @param {string} moduleID - The ID of the module.
+// @param {string} contentID - The ID of the content to attach.
+// @returns {error} - An error if there was a problem attaching the content.
+func (c *Client) AttachContent(moduleID string, contentID string) error {
+	_, err := c.UpdateModuleMembers(moduleID, func(current *models.Module) models.UpdateModuleRequest {
+		contents := *current.FlattenContentIdentifiers()
+		if !shared.ContainsString(contents, contentID) {
+			contents = append(contents, contentID)
+		}
+
+		return models.UpdateModuleRequest{Contents: &contents}
+	})
+
+	return err
+}
+
+// `DetachContent` removes a content from a module, leaving the other contents untouched.
+// @param {string} moduleID - The ID of the module.
+// @param {string} contentID - The ID of the content to detach.
+// @returns {error} - An error if there was a problem detaching the content.
+func (c *Client) DetachContent(moduleID string, contentID string) error {
+	_, err := c.UpdateModuleMembers(moduleID, func(current *models.Module) models.UpdateModuleRequest {
+		contents := removeString(*current.FlattenContentIdentifiers(), contentID)
+
+		return models.UpdateModuleRequest{Contents: &contents}
+	})
+
+	return err
+}
+
+// `AttachSubmodule` adds a submodule to a module, leaving the other submodules untouched.
+// @param {string} moduleID - The ID of the module.
+// @param {string} submoduleID - The ID of the submodule to attach.
+// @returns {error} - An error if there was a problem attaching the submodule.
+func (c *Client) AttachSubmodule(moduleID string, submoduleID string) error {
+	_, err := c.UpdateModuleMembers(moduleID, func(current *models.Module) models.UpdateModuleRequest {
+		modules := *current.FlattenModuleIdentifiers()
+		if !shared.ContainsString(modules, submoduleID) {
+			modules = append(modules, submoduleID)
+		}
+
+		return models.UpdateModuleRequest{Modules: &modules}
+	})
+
+	return err
+}
+
+// `DetachSubmodule` removes a submodule from a module, leaving the other submodules untouched.
+// @param {string} moduleID - The ID of the module.
+// @param {string} submoduleID - The ID of the submodule to detach.
+// @returns {error} - An error if there was a problem detaching the submodule.
+func (c *Client) DetachSubmodule(moduleID string, submoduleID string) error {
+	_, err := c.UpdateModuleMembers(moduleID, func(current *models.Module) models.UpdateModuleRequest {
+		modules := removeString(*current.FlattenModuleIdentifiers(), submoduleID)
+
+		return models.UpdateModuleRequest{Modules: &modules}
+	})
+
+	return err
+}
+
+// `removeString` returns a copy of a slice without the given string.
+func removeString(slice []string, str string) []string {
+	result := make([]string, 0)
+
+	for _, s := range slice {
+		if s != str {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"os"
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/module"
+	"testing"
+)
+
+func TestModuleAttachment(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	newContent := func() *content.Content {
+		co, err := c.CreateContent(content.Content{
+			Name:        "Test content",
+			Description: "This is a test content",
+			Type:        "exercise",
+			Reward:      10,
+			RootComponent: content.Component{
+				Type:        "container",
+				Orientation: "vertical",
+				Data: content.ComponentData{
+					Components: []content.Component{},
+				},
+			},
+		})
+		if err != nil {
+			t.Errorf("Error creating content: %s", err)
+		}
+		return co
+	}
+
+	owned := newContent()
+	attached := newContent()
+
+	mo, err := c.CreateModule(module.Module{
+		Name:        "Test",
+		Description: "This is a test module.",
+		Reward:      10,
+		Type:        module.TypeChallenge,
+		Tags:        []string{"test"},
+		Modules:     []module.ModuleIdentifier{},
+		Contents:    []module.ContentIdentifier{{ID: owned.ID}},
+	})
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	err = c.AttachContent(mo.ID, attached.ID)
+	if err != nil {
+		t.Errorf("Error attaching content: %s", err)
+	}
+
+	attachedModule, err := c.GetModule(mo.ID)
+	if err != nil {
+		t.Errorf("Error reading module: %s", err)
+	}
+	if len(attachedModule.Contents) != 2 {
+		t.Errorf("Error checking attached module: field Contents expected 2 contents got %v", attachedModule.Contents)
+	}
+
+	err = c.DetachContent(mo.ID, attached.ID)
+	if err != nil {
+		t.Errorf("Error detaching content: %s", err)
+	}
+
+	detachedModule, err := c.GetModule(mo.ID)
+	if err != nil {
+		t.Errorf("Error reading module: %s", err)
+	}
+	if len(detachedModule.Contents) != 1 || detachedModule.Contents[0].ID != owned.ID {
+		t.Errorf("Error checking detached module: field Contents expected [%s] got %v", owned.ID, detachedModule.Contents)
+	}
+
+	err = c.DeleteModule(mo.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+	for _, ID := range []string{owned.ID, attached.ID} {
+		err = c.DeleteContent(ID)
+		if err != nil {
+			t.Errorf("Error deleting content: %s", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	models "polycode-provider/client/models/module"
+)
+
+// `ModuleGraphError` is returned when a module dependency graph is invalid.
+// @property {string} Reason - Why the graph is invalid.
+// @property {[]string} Path - The IDs of the modules leading to the offending module, root first.
+type ModuleGraphError struct {
+	Reason string
+	Path   []string
+}
+
+func (e *ModuleGraphError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, strings.Join(e.Path, " -> "))
+}
+
+// `ValidateModuleGraph` walks the submodules of a module recursively and checks that the graph is valid.
+// The module itself is taken as given, so it can be a planned change that does not exist in the API yet,
+// every submodule is fetched with `GetModule`.
+// The graph is rejected if a module references itself, if it holds a cycle, or if a module holds
+// a module of a type that cannot be nested.
+// @param {Module} module - The module to validate, its ID can be empty if it is not created yet.
+// @returns {error} - A `ModuleGraphError` if the graph is invalid, or an error if a module could not be fetched.
+func (c *Client) ValidateModuleGraph(module models.Module) error {
+	walker := moduleGraphWalker{
+		client:  c,
+		root:    module,
+		visited: make(map[string]bool),
+	}
+
+	return walker.walk(&module, []string{moduleGraphLabel(module.ID)})
+}
+
+// `moduleGraphWalker` holds the state of a depth first walk over the module graph.
+// @property client - The client used to fetch the submodules.
+// @property {Module} root - The module the walk started from, used instead of its API version.
+// @property visited - The IDs of the modules whose subgraph is already validated.
+type moduleGraphWalker struct {
+	client  *Client
+	root    models.Module
+	visited map[string]bool
+}
+
+func (w *moduleGraphWalker) walk(module *models.Module, path []string) error {
+	for _, child := range module.Modules {
+		if child.ID == "" {
+			continue
+		}
+
+		childPath := append(append(make([]string, 0, len(path)+1), path...), child.ID)
+
+		if child.ID == module.ID {
+			return &ModuleGraphError{Reason: "module references itself", Path: childPath}
+		}
+		for _, ancestor := range path {
+			if ancestor == child.ID {
+				return &ModuleGraphError{Reason: "module dependency cycle", Path: childPath}
+			}
+		}
+		if w.visited[child.ID] {
+			continue
+		}
+
+		childModule := &w.root
+		if child.ID != w.root.ID {
+			var err error
+			childModule, err = w.client.GetModule(child.ID)
+			if err != nil {
+				return fmt.Errorf("unable to get module %s: %w", child.ID, err)
+			}
+		}
+
+		if !models.CanBeNested(childModule.Type) {
+			return &ModuleGraphError{
+				Reason: fmt.Sprintf("module of type %s cannot be held by another module", childModule.Type),
+				Path:   childPath,
+			}
+		}
+
+		err := w.walk(childModule, childPath)
+		if err != nil {
+			return err
+		}
+
+		w.visited[child.ID] = true
+	}
+
+	return nil
+}
+
+// `moduleGraphLabel` returns the label of a module in a graph path.
+func moduleGraphLabel(ID string) string {
+	if ID == "" {
+		return "(new module)"
+	}
+
+	return ID
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"polycode-provider/client/models/module"
+	"testing"
+)
+
+func TestModuleGraphValidation(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	m := module.Module{
+		Name:        "Test",
+		Description: "This is a nested test module.",
+		Reward:      10,
+		Type:        module.TypeSubmodule,
+		Tags:        []string{"test"},
+		Data:        module.ModuleData{},
+		Modules:     []module.ModuleIdentifier{},
+		Contents:    []module.ContentIdentifier{},
+	}
+
+	submodule, err := c.CreateModule(m)
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	m.Type = module.TypeChallenge
+	m.Modules = []module.ModuleIdentifier{{ID: submodule.ID}}
+
+	challenge, err := c.CreateModule(m)
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	err = c.ValidateModuleGraph(module.Module{Type: module.TypeChallenge, Modules: []module.ModuleIdentifier{{ID: submodule.ID}}})
+	if err != nil {
+		t.Errorf("Error validating valid module graph: %s", err)
+	}
+
+	var graphErr *ModuleGraphError
+
+	err = c.ValidateModuleGraph(module.Module{ID: submodule.ID, Type: module.TypeSubmodule, Modules: []module.ModuleIdentifier{{ID: submodule.ID}}})
+	if !errors.As(err, &graphErr) {
+		t.Errorf("Error validating self referencing module graph: expected ModuleGraphError got %v", err)
+	}
+
+	err = c.ValidateModuleGraph(module.Module{ID: submodule.ID, Type: module.TypeSubmodule, Modules: []module.ModuleIdentifier{{ID: challenge.ID}}})
+	if !errors.As(err, &graphErr) {
+		t.Errorf("Error validating module graph holding a challenge: expected ModuleGraphError got %v", err)
+	}
+
+	err = c.DeleteModule(challenge.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+	err = c.DeleteModule(submodule.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+}
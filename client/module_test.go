@@ -1,11 +1,11 @@
 package client
 
 import (
-	"fmt"
 	"os"
 	"polycode-provider/client/models/content"
 	"polycode-provider/client/models/module"
 	"testing"
+	"time"
 )
 
 func TestModuleLifecycle(t *testing.T) {
@@ -161,7 +161,7 @@ func TestNestedModules(t *testing.T) {
 		t.Errorf("Error creating module: %s", err)
 	}
 
-	fmt.Sprintln(res1)
+	t.Logf("%+v", res1)
 
 	createdModule2, err := c.GetModule(res1.ID)
 	if err != nil {
@@ -222,3 +222,139 @@ func TestNestedModules(t *testing.T) {
 		t.Errorf("Error deleting module: %s", err)
 	}
 }
+
+func TestModuleMetadata(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	startsAt := time.Date(2026, time.September, 1, 8, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, time.December, 20, 18, 0, 0, 0, time.UTC)
+
+	m := module.Module{
+		Name:        "Test",
+		Description: "This is a test module.",
+		Reward:      10,
+		Type:        module.TypeChallenge,
+		Tags:        []string{"test"},
+		Data: module.ModuleData{
+			Visibility:       module.VisibilityPublished,
+			Difficulty:       module.DifficultyMedium,
+			EstimatedMinutes: 90,
+			StartsAt:         &startsAt,
+			EndsAt:           &endsAt,
+		},
+		Modules:  []module.ModuleIdentifier{},
+		Contents: []module.ContentIdentifier{},
+	}
+
+	res, err := c.CreateModule(m)
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	createdModule, err := c.GetModule(res.ID)
+	if err != nil {
+		t.Errorf("Error reading created module: %s", err)
+	}
+
+	if createdModule.Data.Visibility != m.Data.Visibility {
+		t.Errorf("Error checking created module: field Visibility expected %s got %s", m.Data.Visibility, createdModule.Data.Visibility)
+	}
+	if createdModule.Data.Difficulty != m.Data.Difficulty {
+		t.Errorf("Error checking created module: field Difficulty expected %s got %s", m.Data.Difficulty, createdModule.Data.Difficulty)
+	}
+	if createdModule.Data.EstimatedMinutes != m.Data.EstimatedMinutes {
+		t.Errorf("Error checking created module: field EstimatedMinutes expected %d got %d", m.Data.EstimatedMinutes, createdModule.Data.EstimatedMinutes)
+	}
+	if createdModule.Data.StartsAt == nil || !createdModule.Data.StartsAt.Equal(startsAt) {
+		t.Errorf("Error checking created module: field StartsAt expected %s got %v", startsAt, createdModule.Data.StartsAt)
+	}
+	if createdModule.Data.EndsAt == nil || !createdModule.Data.EndsAt.Equal(endsAt) {
+		t.Errorf("Error checking created module: field EndsAt expected %s got %v", endsAt, createdModule.Data.EndsAt)
+	}
+
+	err = c.DeleteModule(createdModule.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+}
+
+func TestModulePatch(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	submodule, err := c.CreateModule(module.Module{
+		Name:        "Test",
+		Description: "This is a nested test module.",
+		Reward:      10,
+		Type:        module.TypeSubmodule,
+		Tags:        []string{"test"},
+		Modules:     []module.ModuleIdentifier{},
+		Contents:    []module.ContentIdentifier{},
+	})
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	res, err := c.CreateModule(module.Module{
+		Name:        "Test",
+		Description: "This is a test module.",
+		Reward:      10,
+		Type:        module.TypeChallenge,
+		Tags:        []string{"test"},
+		Modules:     []module.ModuleIdentifier{{ID: submodule.ID}},
+		Contents:    []module.ContentIdentifier{},
+	})
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	name := "Renamed test"
+	res, err = c.PatchModule(res.ID, module.UpdateModuleRequest{Name: &name}, "")
+	if err != nil {
+		t.Errorf("Error patching module: %s", err)
+	}
+
+	patchedModule, err := c.GetModule(res.ID)
+	if err != nil {
+		t.Errorf("Error reading patched module: %s", err)
+	}
+
+	if patchedModule.Name != name {
+		t.Errorf("Error checking patched module: field Name expected %s got %s", name, patchedModule.Name)
+	}
+	if len(patchedModule.Modules) != 1 || patchedModule.Modules[0].ID != submodule.ID {
+		t.Errorf("Error checking patched module: field Modules expected [%s] got %v", submodule.ID, patchedModule.Modules)
+	}
+
+	err = c.DeleteModule(patchedModule.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+	err = c.DeleteModule(submodule.ID)
+	if err != nil {
+		t.Errorf("Error deleting module: %s", err)
+	}
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"fmt"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/item"
+	"polycode-provider/client/models/module"
+	"polycode-provider/client/shared"
+)
+
+// Kinds of nodes in a module tree.
+const (
+	ModuleTreeNodeModule  = "module"
+	ModuleTreeNodeContent = "content"
+)
+
+// `DefaultTreeConcurrency` is the default number of requests sent in parallel when fetching a module tree.
+const DefaultTreeConcurrency = 4
+
+// `ModuleTreeNode` is a module or a content in a module tree.
+// @property {string} Kind - The kind of node, either `module` or `content`.
+// @property {string} ID - The ID of the module or the content.
+// @property {string} ParentID - The ID of the parent module, empty for the root module.
+// @property {int} Depth - The depth of the node, 0 for the root module.
+// @property {*Module} Module - The module (only if kind = module).
+// @property {*Content} Content - The content (only if kind = content).
+type ModuleTreeNode struct {
+	Kind     string
+	ID       string
+	ParentID string
+	Depth    int
+	Module   *module.Module
+	Content  *content.Content
+}
+
+// `ModuleTree` is a module with all its submodules and contents resolved recursively.
+// @property {[]ModuleTreeNode} Nodes - The flattened nodes of the tree, in depth first order.
+// @property {int64} TotalReward - The sum of the rewards of every module and content in the tree.
+// @property {int} ContentCount - The number of contents in the tree.
+// @property {int} EditorCount - The number of editor components in the contents of the tree.
+// @property {int64} TotalHintCost - The sum of the costs of the hints referenced by the editors of the tree. Like the
+// rewards, a hint is counted once per reference: once per editor referencing it, and once per occurrence of the
+// content holding the editor. The other items, e.g. time extensions, are not counted.
+type ModuleTree struct {
+	Nodes         []ModuleTreeNode
+	TotalReward   int64
+	ContentCount  int
+	EditorCount   int
+	TotalHintCost int64
+}
+
+// `GetModuleTree` gets a module and resolves its submodules, contents and hints recursively.
+// Every level of the tree is fetched in parallel, with at most `concurrency` requests in flight.
+// A module or a content referenced several times is fetched once but appears once per reference.
+// @param {string} ID - The ID of the root module.
+// @param {int} concurrency - The maximum number of requests in flight, `DefaultTreeConcurrency` if not positive.
+// @returns {ModuleTree} - The resolved tree.
+// @returns {error} - A `BulkError` if some nodes of a level could not be fetched, or an error if the tree holds
+// a cycle.
+func (c *Client) GetModuleTree(ID string, concurrency int) (*ModuleTree, error) {
+	if ID == "" {
+		return nil, fmt.Errorf("empty ID")
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultTreeConcurrency
+	}
+
+	fetcher := moduleTreeFetcher{
+		client:      c,
+		concurrency: concurrency,
+		modules:     make(map[string]*module.Module),
+		contents:    make(map[string]*content.Content),
+		items:       make(map[string]*item.Item),
+	}
+
+	err := fetcher.fetch(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := ModuleTree{Nodes: make([]ModuleTreeNode, 0)}
+	err = fetcher.build(&tree, ID, "", 0, []string{ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tree, nil
+}
+
+// `moduleTreeFetcher` fetches every node of a module tree, level by level.
+// @property client - The client used to fetch the nodes.
+// @property {int} concurrency - The maximum number of requests in flight.
+// @property modules - The fetched modules by ID.
+// @property contents - The fetched contents by ID.
+// @property items - The fetched items by ID.
+type moduleTreeFetcher struct {
+	client      *Client
+	concurrency int
+	modules     map[string]*module.Module
+	contents    map[string]*content.Content
+	items       map[string]*item.Item
+}
+
+func (f *moduleTreeFetcher) fetch(rootID string) error {
+	moduleIDs := []string{rootID}
+
+	for len(moduleIDs) > 0 {
+		modules, err := runBulk(moduleIDs, f.concurrency, f.client.GetModule)
+		if err != nil {
+			return err
+		}
+
+		nextModuleIDs := make([]string, 0)
+		contentIDs := make([]string, 0)
+		for i, mo := range modules {
+			f.modules[moduleIDs[i]] = mo
+
+			for _, child := range mo.Modules {
+				if _, ok := f.modules[child.ID]; !ok && !shared.ContainsString(nextModuleIDs, child.ID) && !shared.ContainsString(moduleIDs, child.ID) {
+					nextModuleIDs = append(nextModuleIDs, child.ID)
+				}
+			}
+			for _, child := range mo.Contents {
+				if _, ok := f.contents[child.ID]; !ok && !shared.ContainsString(contentIDs, child.ID) {
+					contentIDs = append(contentIDs, child.ID)
+				}
+			}
+		}
+
+		contents, err := runBulk(contentIDs, f.concurrency, f.client.GetContent)
+		if err != nil {
+			return err
+		}
+
+		itemIDs := make([]string, 0)
+		for i, co := range contents {
+			f.contents[contentIDs[i]] = co
+
+			for _, editor := range editorComponents(co.RootComponent) {
+				for _, it := range editor.Data.Items {
+					if _, ok := f.items[it.ID]; !ok && !shared.ContainsString(itemIDs, it.ID) {
+						itemIDs = append(itemIDs, it.ID)
+					}
+				}
+			}
+		}
+
+		items, err := runBulk(itemIDs, f.concurrency, f.client.GetItem)
+		if err != nil {
+			return err
+		}
+		for i, it := range items {
+			f.items[itemIDs[i]] = it
+		}
+
+		moduleIDs = nextModuleIDs
+	}
+
+	return nil
+}
+
+func (f *moduleTreeFetcher) build(tree *ModuleTree, ID string, parentID string, depth int, path []string) error {
+	mo := f.modules[ID]
+
+	tree.Nodes = append(tree.Nodes, ModuleTreeNode{
+		Kind:     ModuleTreeNodeModule,
+		ID:       ID,
+		ParentID: parentID,
+		Depth:    depth,
+		Module:   mo,
+	})
+	tree.TotalReward += mo.Reward
+
+	for _, child := range mo.Modules {
+		childPath := append(append(make([]string, 0, len(path)+1), path...), child.ID)
+		if shared.ContainsString(path, child.ID) {
+			return &ModuleGraphError{Reason: "module dependency cycle", Path: childPath}
+		}
+
+		err := f.build(tree, child.ID, ID, depth+1, childPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range mo.Contents {
+		co := f.contents[child.ID]
+
+		tree.Nodes = append(tree.Nodes, ModuleTreeNode{
+			Kind:     ModuleTreeNodeContent,
+			ID:       child.ID,
+			ParentID: ID,
+			Depth:    depth + 1,
+			Content:  co,
+		})
+		tree.TotalReward += co.Reward
+		tree.ContentCount++
+		for _, editor := range editorComponents(co.RootComponent) {
+			tree.EditorCount++
+			for _, reference := range editor.Data.Items {
+				if it := f.items[reference.ID]; it != nil && it.Type == item.TypeHint {
+					tree.TotalHintCost += it.Cost
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// `editorComponents` returns every editor component nested in a component.
+func editorComponents(component content.Component) []content.Component {
+	result := make([]content.Component, 0)
+
+	if component.Type == "editor" {
+		result = append(result, component)
+	}
+	for _, child := range component.Data.Components {
+		result = append(result, editorComponents(child)...)
+	}
+
+	return result
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"os"
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/item"
+	"polycode-provider/client/models/module"
+	"testing"
+)
+
+func TestModuleTree(t *testing.T) {
+	username := "admin@gmail.com"
+	password := "12345678"
+	if os.Getenv("POLYCODE_USERNAME") != "" {
+		username = os.Getenv("POLYCODE_USERNAME")
+	}
+	if os.Getenv("POLYCODE_PASSWORD") != "" {
+		password = os.Getenv("POLYCODE_PASSWORD")
+	}
+
+	c, err := NewClient(nil, &username, &password)
+	if err != nil {
+		t.Errorf("Error creating client: %s", err)
+	}
+
+	hint, err := c.CreateItem(item.Item{
+		Type: item.TypeHint,
+		Cost: 10,
+		Data: item.ItemData{
+			Hint: &item.HintData{Text: "This is a test hint"},
+		},
+	})
+	if err != nil {
+		t.Errorf("Error creating hint: %s", err)
+	}
+
+	co, err := c.CreateContent(content.Content{
+		Name:        "Test content",
+		Description: "This is a test content",
+		Type:        "exercise",
+		Reward:      10,
+		Data:        content.ContentData{},
+		RootComponent: content.Component{
+			Type:        "container",
+			Orientation: "vertical",
+			Data: content.ComponentData{
+				Components: []content.Component{
+					{
+						Type: "editor",
+						Data: content.ComponentData{
+							EditorSettings: content.EditorSettings{
+								Languages: []content.Language{{Language: "PYTHON"}},
+							},
+							Validators: []content.Validator{},
+							Items:      []content.ItemIdentifier{{ID: hint.ID}},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Error creating content: %s", err)
+	}
+
+	submodule, err := c.CreateModule(module.Module{
+		Name:        "Test",
+		Description: "This is a nested test module.",
+		Reward:      20,
+		Type:        module.TypeSubmodule,
+		Tags:        []string{"test"},
+		Modules:     []module.ModuleIdentifier{},
+		Contents:    []module.ContentIdentifier{{ID: co.ID}},
+	})
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	challenge, err := c.CreateModule(module.Module{
+		Name:        "Test",
+		Description: "This is a test module.",
+		Reward:      30,
+		Type:        module.TypeChallenge,
+		Tags:        []string{"test"},
+		Modules:     []module.ModuleIdentifier{{ID: submodule.ID}},
+		Contents:    []module.ContentIdentifier{},
+	})
+	if err != nil {
+		t.Errorf("Error creating module: %s", err)
+	}
+
+	tree, err := c.GetModuleTree(challenge.ID, 2)
+	if err != nil {
+		t.Errorf("Error reading module tree: %s", err)
+	}
+
+	if len(tree.Nodes) != 3 {
+		t.Errorf("Error checking module tree: field Nodes expected %d nodes got %d", 3, len(tree.Nodes))
+	}
+	if tree.Nodes[2].ParentID != submodule.ID || tree.Nodes[2].Depth != 2 {
+		t.Errorf("Error checking module tree: content node expected parent %s at depth 2 got %s at depth %d", submodule.ID, tree.Nodes[2].ParentID, tree.Nodes[2].Depth)
+	}
+	if tree.TotalReward != 60 {
+		t.Errorf("Error checking module tree: field TotalReward expected %d got %d", 60, tree.TotalReward)
+	}
+	if tree.EditorCount != 1 {
+		t.Errorf("Error checking module tree: field EditorCount expected %d got %d", 1, tree.EditorCount)
+	}
+	if tree.TotalHintCost != 10 {
+		t.Errorf("Error checking module tree: field TotalHintCost expected %d got %d", 10, tree.TotalHintCost)
+	}
+
+	for _, ID := range []string{challenge.ID, submodule.ID} {
+		err = c.DeleteModule(ID)
+		if err != nil {
+			t.Errorf("Error deleting module: %s", err)
+		}
+	}
+	err = c.DeleteContent(co.ID)
+	if err != nil {
+		t.Errorf("Error deleting content: %s", err)
+	}
+	err = c.DeleteItem(hint.ID)
+	if err != nil {
+		t.Errorf("Error deleting hint: %s", err)
+	}
+}
@@ -0,0 +1,402 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Statuses of an asynchronous operation of the API.
+const (
+	OperationPending   = "pending"
+	OperationRunning   = "running"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+// `DefaultOperationPollInterval` is the delay before the first poll of an operation, doubled after every poll.
+const DefaultOperationPollInterval = time.Second
+
+// `MaxOperationPollInterval` is the longest delay between two polls of an operation.
+const MaxOperationPollInterval = 15 * time.Second
+
+// `DefaultOperationTimeout` is how long an operation is waited for when its context has no deadline.
+const DefaultOperationTimeout = 20 * time.Minute
+
+// `Operation` is a request the API accepted with 202 Accepted and processes asynchronously, e.g. the creation
+// of a content whose editors are precompiled and whose validators are run.
+// @property {string} ID - The unique identifier for the operation.
+// @property {string} URL - The URL the status of the operation is polled from.
+// @property {string} Status - The status of the operation, see the `Operation*` constants.
+// @property {string} ResourceID - The ID of the resource created or updated by the operation, if any.
+// @property {string} ResourceURL - The URL of the resource created or updated by the operation, if any.
+// @property {*OperationError} Error - Why the operation failed, nil if it did not fail.
+type Operation struct {
+	ID          string
+	URL         string
+	Status      string
+	ResourceID  string
+	ResourceURL string
+	Error       *OperationError
+}
+
+// `Done` tells whether the operation completed, successfully or not.
+func (o *Operation) Done() bool {
+	return o.Status == OperationSucceeded || o.Status == OperationFailed
+}
+
+// `OperationWaiter` waits for an asynchronous operation of the API until it completes, within the given context.
+// @param ctx - The context of the request the API accepted.
+// @param {*Client} client - The client that sent the request.
+// @param {*Operation} operation - The operation, as returned by the API when it accepted the request.
+// @returns {Operation} - The completed operation.
+// @returns {error} - An `OperationError` if the operation failed, or an error if it could not be waited for.
+type OperationWaiter func(ctx context.Context, client *Client, operation *Operation) (*Operation, error)
+
+// `setDefaultURL` sets the URL of an operation the API did not give the URL of to `/operation/<id>`.
+func (o *Operation) setDefaultURL() error {
+	if o.URL != "" {
+		return nil
+	}
+	if o.ID == "" {
+		return fmt.Errorf("operation without ID nor URL")
+	}
+	o.URL = fmt.Sprintf("/operation/%s", o.ID)
+
+	return nil
+}
+
+// `OperationError` is the failure of an asynchronous operation of the API.
+// @property {string} OperationID - The ID of the operation that failed.
+// @property {string} ResourceID - The ID of the resource the operation created or updated before it failed,
+// if the API kept it.
+// @property {string} Code - The code of the failure, e.g. `compilation_failed`.
+// @property {string} Message - The description of the failure.
+// @property {[]ValidationError} Details - The fields of the request the failure is about, see `ValidationErrors`.
+type OperationError struct {
+	OperationID string
+	ResourceID  string
+	Code        string
+	Message     string
+	Details     []ValidationError
+}
+
+func (e *OperationError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = "unknown error"
+	}
+	if e.Code != "" {
+		message = fmt.Sprintf("%s (%s)", message, e.Code)
+	}
+
+	details := make([]string, 0)
+	for _, detail := range e.Details {
+		if detail.Path == "" {
+			details = append(details, detail.Message)
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: %s", detail.Path, detail.Message))
+	}
+	if len(details) > 0 {
+		message = fmt.Sprintf("%s: %s", message, strings.Join(details, "; "))
+	}
+
+	return fmt.Sprintf("operation %s failed: %s", e.OperationID, message)
+}
+
+// `OperationResponse` is the response body of the operation endpoint, and of the requests accepted with
+// 202 Accepted.
+type OperationResponse struct {
+	Metadata Metadata              `json:"metadata"`
+	Data     OperationResponseData `json:"data"`
+}
+
+// `OperationResponseData` is the status of an operation.
+// @property {string} ID - The unique identifier for the operation.
+// @property {string} Status - The status of the operation.
+// @property {string} ResourceID - The ID of the resource created or updated by the operation.
+// @property {string} ResourceURL - The path or URL of the resource created or updated by the operation.
+// @property {*OperationErrorResponse} Error - Why the operation failed.
+type OperationResponseData struct {
+	ID          string                  `json:"id"`
+	Status      string                  `json:"status"`
+	ResourceID  string                  `json:"resourceId"`
+	ResourceURL string                  `json:"resourceUrl"`
+	Error       *OperationErrorResponse `json:"error"`
+}
+
+// `OperationErrorResponse` is the failure of an operation.
+// @property {string} Code - The code of the failure.
+// @property {string} Message - The description of the failure.
+// @property {[]OperationErrorDetailResponse} Details - The fields of the request the failure is about.
+type OperationErrorResponse struct {
+	Code    string                         `json:"code"`
+	Message string                         `json:"message"`
+	Details []OperationErrorDetailResponse `json:"details"`
+}
+
+// `OperationErrorDetailResponse` is a field of the request an operation failed because of.
+// @property {string} Path - The path of the field in the request body.
+// @property {string} Message - The description of the error.
+type OperationErrorDetailResponse struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// `IntoOperation` converts the response body into a pointer of an `Operation` struct.
+// @param {string} operationURL - The URL the operation is polled from, used if the API did not give one.
+// @returns {Operation} The operation.
+func (or *OperationResponse) IntoOperation(operationURL string) *Operation {
+	result := &Operation{
+		ID:          or.Data.ID,
+		URL:         operationURL,
+		Status:      or.Data.Status,
+		ResourceID:  or.Data.ResourceID,
+		ResourceURL: or.Data.ResourceURL,
+	}
+
+	if or.Data.Error != nil {
+		result.Error = &OperationError{
+			OperationID: or.Data.ID,
+			ResourceID:  or.Data.ResourceID,
+			Code:        or.Data.Error.Code,
+			Message:     or.Data.Error.Message,
+			Details:     make([]ValidationError, 0),
+		}
+		for _, detail := range or.Data.Error.Details {
+			result.Error.Details = append(result.Error.Details, ValidationError{
+				Path:    normalizeValidationPath(detail.Path),
+				Message: detail.Message,
+			})
+		}
+	}
+
+	return result
+}
+
+// `GetOperation` gets the status of an operation from the API.
+// @param {string} operationURL - The URL of the operation, or its path, e.g. `/operation/<id>`.
+// @returns {Operation} - The operation that was retrieved.
+// @returns {error} - An error if there was a problem getting the operation.
+func (client *Client) GetOperation(operationURL string) (*Operation, error) {
+	operation, _, err := client.pollOperation(context.Background(), operationURL)
+
+	return operation, err
+}
+
+// `WaitForOperation` polls an operation until it completes, waiting longer between every poll, from
+// `OperationPollInterval` up to `MaxOperationPollInterval`, unless the API asks for a delay with `Retry-After`.
+// Waiting stops when the context of the client is done, or after `DefaultOperationTimeout` if it has no deadline.
+// @param {Operation} operation - The operation to wait for, as returned by the API when it accepted the request.
+// @returns {Operation} - The completed operation.
+// @returns {error} - An `OperationError` if the operation failed, or an error if it could not be polled or did not
+// complete in time.
+func (client *Client) WaitForOperation(operation *Operation) (*Operation, error) {
+	ctx := client.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return client.waitForOperation(ctx, operation)
+}
+
+// `waitForOperation` polls an operation like `WaitForOperation`, within the given context.
+func (client *Client) waitForOperation(ctx context.Context, operation *Operation) (*Operation, error) {
+	err := operation.setDefaultURL()
+	if err != nil {
+		return nil, err
+	}
+
+	logCtx := ctx
+	if logCtx == context.Background() && client.LogContext != nil {
+		logCtx = client.LogContext
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultOperationTimeout)
+		defer cancel()
+	}
+
+	interval := client.OperationPollInterval
+	if interval <= 0 {
+		interval = DefaultOperationPollInterval
+	}
+	wait := interval
+	headers := http.Header{}
+
+	for !operation.Done() {
+		if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil && seconds >= 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+
+		tflog.Debug(logCtx, "Waiting for API operation", map[string]interface{}{
+			"operation_id": operation.ID,
+			"status":       operation.Status,
+			"wait":         wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("operation %s did not complete: %w", operation.ID, ctx.Err())
+		}
+
+		next, nextHeaders, err := client.pollOperation(ctx, operation.URL)
+		if err != nil {
+			return nil, err
+		}
+		if next.ID == "" {
+			next.ID = operation.ID
+		}
+		operation, headers = next, nextHeaders
+
+		wait *= 2
+		if wait > MaxOperationPollInterval {
+			wait = MaxOperationPollInterval
+		}
+	}
+
+	if operation.Status == OperationFailed {
+		if operation.Error == nil {
+			operation.Error = &OperationError{OperationID: operation.ID, ResourceID: operation.ResourceID}
+		}
+		return operation, operation.Error
+	}
+
+	return operation, nil
+}
+
+// `pollOperation` gets the status of an operation, the API answering either 200 OK or 202 Accepted.
+// @returns {Operation} - The operation.
+// @returns {http.Header} - The headers of the response.
+// @returns {error} - An error if there was a problem getting the operation.
+func (client *Client) pollOperation(ctx context.Context, operationURL string) (*Operation, http.Header, error) {
+	operationURL, err := client.resolveURL(operationURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", operationURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, body, headers, err := client.fetch(req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	operationResponse := OperationResponse{}
+	err = json.Unmarshal(body, &operationResponse)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return operationResponse.IntoOperation(operationURL), headers, nil
+}
+
+// `awaitOperation` waits for the operation of a request the API accepted with 202 Accepted, with the
+// `OperationWaiter` of the client if it has one, and then gets the resource the operation created or updated.
+// The operation is read from the response body, and is polled from the `Location` header of the response,
+// or from `/operation/<id>` if there is none. The resource is read from the `resourceUrl` of the operation,
+// or from the path of the request followed by the `resourceId` of the operation, e.g. `/content/<resourceId>`
+// for `POST /content`.
+// @param {http.Request} req - The accepted request.
+// @param {[]byte} body - The body of the 202 Accepted response.
+// @param {http.Header} headers - The headers of the 202 Accepted response.
+// @returns {[]byte} - The body of the resource, nil if the operation has no resource, e.g. for a deletion.
+// @returns {http.Header} - The headers of the resource, nil if the operation has no resource.
+// @returns {error} - An `OperationError` if the operation failed, or an error if it could not be waited for or
+// if the operation of a creation or an update has no resource.
+func (client *Client) awaitOperation(req *http.Request, body []byte, headers http.Header) ([]byte, http.Header, error) {
+	ctx := req.Context()
+	if ctx == context.Background() && client.Context != nil {
+		ctx = client.Context
+	}
+
+	operationResponse := OperationResponse{}
+	if len(body) > 0 {
+		err := json.Unmarshal(body, &operationResponse)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	operation := operationResponse.IntoOperation(headers.Get("Location"))
+	if operation.Status == "" {
+		operation.Status = OperationPending
+	}
+
+	err := operation.setDefaultURL()
+	if err != nil {
+		return nil, nil, err
+	}
+	if client.OperationWaiter != nil {
+		operation, err = client.OperationWaiter(ctx, client, operation)
+	} else {
+		operation, err = client.waitForOperation(ctx, operation)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resourceURL string
+	switch {
+	case operation.ResourceURL != "":
+		resourceURL, err = client.resolveURL(operation.ResourceURL)
+		if err != nil {
+			return nil, nil, err
+		}
+	case operation.ResourceID != "":
+		resource := *req.URL
+		resource.Path = strings.TrimSuffix(resource.Path, "/")
+		resource.RawPath = ""
+		resource.RawQuery = ""
+		if !strings.HasSuffix(resource.Path, "/"+operation.ResourceID) {
+			resource.Path = fmt.Sprintf("%s/%s", resource.Path, url.PathEscape(operation.ResourceID))
+		}
+		resourceURL = resource.String()
+	case req.Method == "POST" || req.Method == "PUT" || req.Method == "PATCH":
+		return nil, nil, fmt.Errorf("operation %s of %s %s succeeded without a resource", operation.ID, req.Method, req.URL.Path)
+	default:
+		return nil, nil, nil
+	}
+
+	resourceReq, err := http.NewRequestWithContext(ctx, "GET", resourceURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client.fetchAPIWithHeaders(resourceReq, nil)
+}
+
+// `resolveURL` resolves a path returned by the API, e.g. `/operation/<id>`, against the host of the client.
+// @param {string} ref - The path or URL.
+// @returns {string} - The absolute URL.
+// @returns {error} - An error if the path or the host is not a valid URL.
+func (client *Client) resolveURL(ref string) (string, error) {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	if refURL.IsAbs() {
+		return ref, nil
+	}
+
+	host, err := url.Parse(strings.TrimSuffix(client.Host, "/") + "/")
+	if err != nil {
+		return "", err
+	}
+
+	return host.ResolveReference(&url.URL{Path: strings.TrimPrefix(refURL.Path, "/"), RawQuery: refURL.RawQuery}).String(), nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/module"
+)
+
+func TestCreateContentAsync(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /content":
+			w.Header().Set("Location", "/operation/op1")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"pending"}}`))
+		case "GET /operation/op1":
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"running"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"succeeded","resourceId":"c1","resourceUrl":"/content/c1"}}`))
+		case "GET /content/c1":
+			w.Header().Set("ETag", `"1"`)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"c1","name":"Hello world","type":"exercise"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), OperationPollInterval: time.Millisecond}
+
+	created, err := c.CreateContent(content.Content{Name: "Hello world"})
+	if err != nil {
+		t.Fatalf("Error creating content: %s", err)
+	}
+	if created.ID != "c1" || created.ETag != `"1"` || polls != 3 {
+		t.Errorf("Expected the content to be read once the operation succeeded, got %+v after %d polls", created, polls)
+	}
+}
+
+func TestOperationResourceID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /content", "PUT /content/c2":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"succeeded","resourceId":"c2"}}`))
+		case "POST /module":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op2","status":"succeeded"}}`))
+		case "GET /content/c2":
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"c2","name":"Hello world","type":"exercise"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), OperationPollInterval: time.Millisecond}
+
+	created, err := c.CreateContent(content.Content{Name: "Hello world"})
+	if err != nil {
+		t.Fatalf("Error creating content: %s", err)
+	}
+	if created.ID != "c2" {
+		t.Errorf("Expected the content to be read from its ID, got %+v", created)
+	}
+
+	updated, err := c.UpdateContent(content.Content{ID: "c2", Name: "Hello world"})
+	if err != nil {
+		t.Fatalf("Error updating content: %s", err)
+	}
+	if updated.ID != "c2" {
+		t.Errorf("Expected the content to be read from its ID, got %+v", updated)
+	}
+
+	_, err = c.CreateModule(module.Module{Name: "Module", Modules: []module.ModuleIdentifier{}, Contents: []module.ContentIdentifier{}})
+	if err == nil || !strings.Contains(err.Error(), "succeeded without a resource") {
+		t.Errorf("Expected an operation without a resource to fail, got %v", err)
+	}
+}
+
+func TestFailedOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op2","status":"failed","resourceId":"c2","error":{
+			"code":"compilation_failed",
+			"message":"The default code does not compile",
+			"details":[{"path":"rootComponent.data.components.1.data.editorSettings.languages.0.defaultCode","message":"syntax error"}]
+		}}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), OperationPollInterval: time.Millisecond}
+
+	_, err := c.WaitForOperation(&Operation{ID: "op2", Status: OperationRunning})
+
+	var operationErr *OperationError
+	if !errors.As(err, &operationErr) || operationErr.ResourceID != "c2" || operationErr.Code != "compilation_failed" {
+		t.Fatalf("Expected the failure of the operation, got %v", err)
+	}
+
+	validationErrors := ValidationErrors(err)
+	if len(validationErrors) != 1 || validationErrors[0].Path != "rootComponent.data.components[1].data.editorSettings.languages[0].defaultCode" {
+		t.Errorf("Expected the details of the failure, got %v", validationErrors)
+	}
+}
+
+func TestOperationTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op3","status":"running"}}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := (&Client{Host: server.URL, HTTPClient: server.Client(), OperationPollInterval: time.Millisecond}).WithContext(ctx)
+
+	err := c.DeleteContent("c3")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the operation to stop at the deadline, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"polycode-provider/client/models/auth"
+)
+
+// `Option` configures a client created with `New`.
+type Option func(*Client)
+
+// `Middleware` wraps the sending of the requests of a client, in the style of an `http.RoundTripper`.
+// A middleware can change the request before calling `next`, and inspect or replace its response,
+// e.g. to add headers, record metrics or audit the calls made to the API.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// `RoundTripperFunc` is a function implementing `http.RoundTripper`, used to write middlewares.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// `WithHTTPClient` makes the client send its requests with the given HTTP client.
+// @param {*http.Client} httpClient - The HTTP client, with its own timeout and transport.
+// @returns {Option} - The option.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.HTTPClient = httpClient
+	}
+}
+
+// `WithHost` sets the API server the client interacts with.
+// @param {string} host - The hostname of the API server, e.g. `http://localhost:3000`.
+// @returns {Option} - The option.
+func WithHost(host string) Option {
+	return func(client *Client) {
+		client.Host = host
+	}
+}
+
+// `WithToken` authenticates the client with an access token, the client does not log in.
+// @param {string} token - The access token.
+// @returns {Option} - The option.
+func WithToken(token string) Option {
+	return func(client *Client) {
+		client.AccessToken = token
+	}
+}
+
+// `WithCredentials` makes the client log in with a username and a password when it is created.
+// @param {string} username - The username to use for authentication.
+// @param {string} password - The password to use for authentication.
+// @returns {Option} - The option.
+func WithCredentials(username, password string) Option {
+	return func(client *Client) {
+		client.Auth = auth.Credentials{
+			Username: username,
+			Password: password,
+		}
+	}
+}
+
+// `WithRequestTimeout` sets the timeout of the requests that are not bounded by the deadline of their context.
+// @param {time.Duration} timeout - The timeout of a request, 0 for no timeout.
+// @returns {Option} - The option.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		client.RequestTimeout = timeout
+	}
+}
+
+// `WithOperationPollInterval` sets the delay before the first poll of an asynchronous operation of the API,
+// the delay doubling after every poll.
+// @param {time.Duration} interval - The delay, `DefaultOperationPollInterval` if not positive.
+// @returns {Option} - The option.
+func WithOperationPollInterval(interval time.Duration) Option {
+	return func(client *Client) {
+		client.OperationPollInterval = interval
+	}
+}
+
+// `WithOperationWaiter` sets the function waiting for the asynchronous operations of the API, instead of
+// `WaitForOperation`.
+// @param {OperationWaiter} waiter - The function waiting for an operation.
+// @returns {Option} - The option.
+func WithOperationWaiter(waiter OperationWaiter) Option {
+	return func(client *Client) {
+		client.OperationWaiter = waiter
+	}
+}
+
+// `WithUserAgent` sets the `User-Agent` header of the requests of the client.
+// @param {string} userAgent - The user agent, e.g. `my-tool/1.0`.
+// @returns {Option} - The option.
+func WithUserAgent(userAgent string) Option {
+	return func(client *Client) {
+		client.UserAgent = userAgent
+	}
+}
+
+// `WithMiddleware` adds middlewares to the client, every request goes through them in order, the first
+// middleware given being the outermost one.
+// @param {...Middleware} middlewares - The middlewares to add.
+// @returns {Option} - The option.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(client *Client) {
+		client.Middlewares = append(client.Middlewares, middlewares...)
+	}
+}
+
+// `roundTrip` sends a request with the HTTP client of the client through its middlewares.
+// @param {http.Request} req - The request to send.
+// @returns {*http.Response} - The response.
+// @returns {error} - An error if the request could not be sent.
+func (client *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	var transport http.RoundTripper = RoundTripperFunc(client.HTTPClient.Do)
+
+	for i := len(client.Middlewares) - 1; i >= 0; i-- {
+		transport = client.Middlewares[i](transport)
+	}
+
+	return transport.RoundTrip(req)
+}
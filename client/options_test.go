@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/token" {
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"accessToken":"logged-in"}}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("User-Agent") != "polycode-test/1.0" {
+			t.Errorf("Unexpected headers: %v", r.Header)
+		}
+		if r.Header.Get("X-Middlewares") != "first,second" {
+			t.Errorf("Expected the middlewares to run in order, got %q", r.Header.Get("X-Middlewares"))
+		}
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+	}))
+	defer server.Close()
+
+	order := make([]string, 0)
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Set("X-Middlewares", strings.Join(order, ","))
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	c, err := New(
+		WithHost(server.URL),
+		WithHTTPClient(server.Client()),
+		WithToken("token"),
+		WithUserAgent("polycode-test/1.0"),
+		WithMiddleware(middleware("first"), middleware("second")),
+	)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	_, err = c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Error getting item: %s", err)
+	}
+
+	c, err = New(WithHost(server.URL), WithHTTPClient(server.Client()), WithCredentials("admin", "12345678"))
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	if c.AccessToken != "logged-in" {
+		t.Errorf("Expected the client to log in, got token %q", c.AccessToken)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	c, err := New(WithHost("http://polycode.invalid"), WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Header:     http.Header{},
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		})
+	}))
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	_, err = c.GetModule("m1")
+	if !IsAPIError(err, APIErrorUnauthorized) {
+		t.Errorf("Expected the response of the middleware, got %v", err)
+	}
+}
+
+func TestRequestTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(WithHost(server.URL), WithHTTPClient(server.Client()), WithRequestTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	if _, err := c.GetItem("i1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request timeout to apply, got %v", err)
+	}
+
+	// A request slower than the request timeout completes within the longer deadline of an operation.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := c.WithContext(ctx).GetItem("i1"); err != nil {
+		t.Errorf("Expected the request to complete within the deadline of the operation, got %s", err)
+	}
+
+	c.RequestTimeout = time.Second
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.WithContext(ctx).GetItem("i1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline of the context to cancel the request, got %v", err)
+	}
+
+	if _, err := c.GetItem("i1"); err != nil {
+		t.Errorf("Expected the request to complete within its timeout, got %s", err)
+	}
+}
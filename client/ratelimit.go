@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// `MaxRateLimitRetries` is the number of times a request rejected with 429 Too Many Requests is sent again.
+const MaxRateLimitRetries = 3
+
+// `Limiter` caps the requests the client sends to the API: a token bucket limits the rate of requests and a
+// semaphore limits the number of requests in flight. A nil `*Limiter` is a valid disabled limiter.
+// @property {float64} rate - The number of tokens added to the bucket per second, 0 for no rate limit.
+// @property {float64} burst - The capacity of the bucket.
+// @property {float64} tokens - The tokens left in the bucket.
+// @property last - When the bucket was last refilled.
+// @property pausedUntil - When the API asked the client to wait until, see `pause`.
+// @property slots - The semaphore of the requests in flight, nil for no concurrency cap.
+type Limiter struct {
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	mu          sync.Mutex
+	slots       chan struct{}
+}
+
+// `NewLimiter` creates a limiter.
+// @param {float64} requestsPerSecond - The maximum number of requests per second, not limited if not positive.
+// @param {int} maxConcurrent - The maximum number of requests in flight, not limited if not positive.
+// @returns {*Limiter} - The limiter, nil if neither the rate nor the concurrency is limited.
+func NewLimiter(requestsPerSecond float64, maxConcurrent int) *Limiter {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return nil
+	}
+
+	limiter := Limiter{last: time.Now()}
+
+	if requestsPerSecond > 0 {
+		limiter.rate = requestsPerSecond
+		// Allow a burst of one second worth of requests, and at least one request.
+		limiter.burst = requestsPerSecond
+		if limiter.burst < 1 {
+			limiter.burst = 1
+		}
+		limiter.tokens = limiter.burst
+	}
+
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return &limiter
+}
+
+// `acquire` waits for a slot and a token before a request is sent.
+// Every successful call must be followed by a call to `release` once the response is read.
+// @param ctx - The context of the request, waiting stops when it is done.
+// @returns {error} - The error of the context if it was done before the request could be sent.
+func (l *Limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			l.release()
+			return ctx.Err()
+		}
+	}
+}
+
+// `reserve` takes a token from the bucket if the client is not paused.
+// @returns {time.Duration} - 0 if a token was taken, otherwise how long to wait before trying again.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// `release` frees the slot taken by `acquire`.
+func (l *Limiter) release() {
+	if l == nil || l.slots == nil {
+		return
+	}
+
+	<-l.slots
+}
+
+// `pause` stops every request of the client from being sent for a while, used when the API answers
+// 429 Too Many Requests so that the other requests in flight do not hit the limit as well.
+// @param {time.Duration} wait - How long to pause the requests.
+func (l *Limiter) pause(wait time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(wait)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	// The bucket is drained so that requests resume at the configured rate rather than in a burst.
+	l.tokens = 0
+	l.last = until
+}
+
+// `sleep` waits for a while, or until the context is done.
+// @param ctx - The context of the request waiting.
+// @param {time.Duration} wait - How long to wait.
+// @returns {error} - The error of the context if it was done before the end of the wait.
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// `retryAfter` returns how long to wait before retrying a request rejected with 429 Too Many Requests.
+// The `Retry-After` header is used when it holds a number of seconds, otherwise the delay doubles
+// with every attempt starting from 1 second.
+// @param {http.Header} headers - The headers of the response.
+// @param {int} attempt - The number of the attempt that was rejected, starting from 0.
+// @returns {time.Duration} - How long to wait, at most 30 seconds.
+func retryAfter(headers http.Header, attempt int) time.Duration {
+	wait := time.Second << attempt
+
+	if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	if wait > 30*time.Second {
+		wait = 30 * time.Second
+	}
+
+	return wait
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), Limiter: NewLimiter(0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			if _, err := c.fetchAPI(req, nil); err != nil {
+				t.Errorf("Error fetching: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	limiter := NewLimiter(100, 0)
+
+	start := time.Now()
+	for i := 0; i < 110; i++ {
+		if err := limiter.acquire(context.Background()); err != nil {
+			t.Fatalf("Error acquiring: %s", err)
+		}
+		limiter.release()
+	}
+
+	// The first 100 requests are a burst, the next 10 are sent at 100 per second.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected the requests after the burst to be delayed, took %s", elapsed)
+	}
+
+	if NewLimiter(0, 0) != nil {
+		t.Errorf("Expected no limits to disable the limiter")
+	}
+}
+
+func TestLimiterContext(t *testing.T) {
+	limiter := NewLimiter(0, 1)
+	if err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("Error acquiring: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.acquire(ctx); err == nil {
+		t.Errorf("Expected waiting for a slot to stop with the context")
+	}
+}
+
+func TestRetryTooManyRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), Limiter: NewLimiter(10, 1)}
+
+	item, err := c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Expected the request to be retried, got %s", err)
+	}
+	if item.ID != "i1" || requests != 2 {
+		t.Errorf("Unexpected item %+v after %d requests", item, requests)
+	}
+}
+
+func TestRetryTooManyRequestsWithoutLimiter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client(), Limiter: nil}
+
+	start := time.Now()
+	_, err := c.GetItem("i1")
+	if err != nil {
+		t.Fatalf("Expected the request to be retried, got %s", err)
+	}
+	if elapsed := time.Since(start); atomic.LoadInt32(&requests) != 2 || elapsed < time.Second {
+		t.Errorf("Expected the request to be retried once after Retry-After, got %d requests in %s", atomic.LoadInt32(&requests), elapsed)
+	}
+
+	// The wait stops with the context of the request.
+	atomic.StoreInt32(&requests, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.WithContext(ctx).GetItem("i1")
+	if !errors.Is(err, context.DeadlineExceeded) || atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("Expected the wait to be canceled, got %v after %d requests", err, atomic.LoadInt32(&requests))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	headers := http.Header{}
+	if wait := retryAfter(headers, 2); wait != 4*time.Second {
+		t.Errorf("Expected a 4s backoff, got %s", wait)
+	}
+
+	headers.Set("Retry-After", "120")
+	if wait := retryAfter(headers, 0); wait != 30*time.Second {
+		t.Errorf("Expected the wait to be capped to 30s, got %s", wait)
+	}
+}
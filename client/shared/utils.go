@@ -1,5 +1,7 @@
 package shared
 
+import "time"
+
 // `ConvertNilString` converts a string to a string pointer, returning nil if the string is empty.
 // @param {string} str - The string to convert.
 // @returns {string} - The converted string.
@@ -21,3 +23,94 @@ func ConvertNilStringPointer(str *string) string {
 
 	return *str
 }
+
+// `ConvertNilInt64Pointer` converts an int64 pointer to an int64, returning 0 if the pointer is nil.
+// @param {int64} i - The int64 to convert.
+// @returns {int64} - The converted int64.
+func ConvertNilInt64Pointer(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
+
+// `ContainsString` tells whether a slice holds a string.
+// @param {[]string} slice - The slice to search.
+// @param {string} str - The string to search for.
+// @returns {bool} - Whether the slice holds the string.
+func ContainsString(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// `ParseNilTimePointer` parses a RFC3339 string pointer into a time pointer,
+// returning nil if the pointer is nil or if the string is not a valid RFC3339 date.
+// @param {string} str - The string to parse.
+// @returns {time.Time} - The parsed time.
+func ParseNilTimePointer(str *string) *time.Time {
+	if str == nil {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, *str)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// `FormatNilTime` formats a time pointer into a RFC3339 string pointer, returning nil if the time is nil.
+// @param {time.Time} t - The time to format.
+// @returns {string} - The formatted time.
+func FormatNilTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	str := t.Format(time.RFC3339)
+
+	return &str
+}
+
+// `Audit` holds the audit information the API keeps about a resource.
+// @property {*time.Time} CreatedAt - When the resource was created, nil if unknown.
+// @property {*time.Time} UpdatedAt - When the resource was last updated, nil if unknown.
+// @property {string} CreatedBy - Who created the resource, empty if unknown.
+// @property {string} UpdatedBy - Who last updated the resource, empty if unknown.
+type Audit struct {
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	CreatedBy string
+	UpdatedBy string
+}
+
+// `AuditResponse` is the audit information returned by the API along with a resource,
+// this structure holds pointers because the API may not return all of them.
+// @property {*string} CreatedAt - The RFC3339 creation date.
+// @property {*string} UpdatedAt - The RFC3339 last update date.
+// @property {*string} CreatedBy - The ID of the user who created the resource.
+// @property {*string} UpdatedBy - The ID of the user who last updated the resource.
+type AuditResponse struct {
+	CreatedAt *string `json:"createdAt"`
+	UpdatedAt *string `json:"updatedAt"`
+	CreatedBy *string `json:"createdBy"`
+	UpdatedBy *string `json:"updatedBy"`
+}
+
+// `IntoAudit` converts the audit response into an `Audit` struct.
+// @returns {Audit} The audit information.
+func (ar *AuditResponse) IntoAudit() Audit {
+	return Audit{
+		CreatedAt: ParseNilTimePointer(ar.CreatedAt),
+		UpdatedAt: ParseNilTimePointer(ar.UpdatedAt),
+		CreatedBy: ConvertNilStringPointer(ar.CreatedBy),
+		UpdatedBy: ConvertNilStringPointer(ar.UpdatedBy),
+	}
+}
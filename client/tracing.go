@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// `tracerName` is the name of the tracer of the API calls.
+const tracerName = "polycode-provider/client"
+
+// `startSpan` starts the span of a request to the API and propagates it to the API in the `traceparent` header.
+// @param ctx - The context of the request.
+// @param {http.Request} req - The request to send, it must carry a request ID.
+// @returns - The context of the span.
+// @returns - The span, ended by `endSpan`.
+func startSpan(ctx context.Context, req *http.Request) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(req.URL.String()),
+			semconv.NetPeerNameKey.String(req.URL.Hostname()),
+			attribute.String("polycode.request_id", req.Header.Get(RequestIDHeader)),
+		),
+	)
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return ctx, span
+}
+
+// `endSpan` records the response of a request to the API and ends its span.
+// @param span - The span started by `startSpan`.
+// @param {int} statusCode - The status code of the response, 0 if the request failed.
+// @param {error} err - The error if the request failed.
+func endSpan(span trace.Span, statusCode int, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	if statusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
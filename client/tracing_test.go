@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "polycode_item.read")
+	c := (&Client{Host: server.URL, HTTPClient: server.Client()}).WithContext(ctx)
+
+	_, err := c.GetItem("i1")
+	parent.End()
+	if !IsAPIError(err, APIErrorNotFound) {
+		t.Fatalf("Expected a not found error, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "HTTP GET" || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Expected the API call to be a child of the operation, got %s with parent %s", span.Name(), span.Parent().SpanID())
+	}
+	if traceparent == "" || traceparent[3:35] != span.SpanContext().TraceID().String() {
+		t.Errorf("Expected the trace to be propagated to the API, got %q", traceparent)
+	}
+
+	attributes := map[string]interface{}{}
+	for _, attribute := range span.Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+	}
+	if attributes["http.method"] != "GET" || attributes["http.status_code"] != int64(404) || attributes["polycode.request_id"] == "" {
+		t.Errorf("Unexpected span attributes: %v", attributes)
+	}
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// `ValidationError` is an invalid field reported by the API when it rejects a request with 400 Bad Request.
+// @property {string} Path - The path of the field in the request body, e.g.
+// `rootComponent.data.components[1].data.validators[0].expected`, empty if the error is not about a field.
+// @property {string} Message - The description of the error.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+// `indexSegment` matches the numeric segments of a dotted path, e.g. `.1` in `components.1.data`.
+var indexSegment = regexp.MustCompile(`\.(\d+)(\.|$)`)
+
+// `ValidationErrors` returns the invalid fields reported by the API in a 400 Bad Request error.
+// Both `{"errors": [{"path": "...", "message": "..."}]}` bodies and the `{"message": ["<path> <message>"]}`
+// bodies of the API framework are understood. The details of a failed asynchronous operation, see
+// `OperationError`, are returned as well.
+// @param {error} err - The error returned by the client.
+// @returns {[]ValidationError} - The invalid fields, empty if the error is not a bad request or is not structured.
+func ValidationErrors(err error) []ValidationError {
+	var operationErr *OperationError
+	if errors.As(err, &operationErr) {
+		return append([]ValidationError{}, operationErr.Details...)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Kind != APIErrorBadRequest {
+		return []ValidationError{}
+	}
+
+	body := struct {
+		Errors []struct {
+			Path     string `json:"path"`
+			Property string `json:"property"`
+			Field    string `json:"field"`
+			Message  string `json:"message"`
+		} `json:"errors"`
+		Message json.RawMessage `json:"message"`
+	}{}
+	if json.Unmarshal([]byte(apiErr.Body), &body) != nil {
+		return []ValidationError{}
+	}
+
+	result := make([]ValidationError, 0)
+
+	for _, e := range body.Errors {
+		path := e.Path
+		if path == "" {
+			path = e.Property
+		}
+		if path == "" {
+			path = e.Field
+		}
+
+		result = append(result, ValidationError{
+			Path:    normalizeValidationPath(path),
+			Message: e.Message,
+		})
+	}
+
+	messages := make([]string, 0)
+	if json.Unmarshal(body.Message, &messages) == nil {
+		for _, message := range messages {
+			path, _, _ := strings.Cut(message, " ")
+			if !strings.ContainsAny(path, ".[") && !isFieldName(path) {
+				path = ""
+			}
+
+			result = append(result, ValidationError{
+				Path:    normalizeValidationPath(path),
+				Message: message,
+			})
+		}
+	}
+
+	return result
+}
+
+// `isFieldName` tells whether a word is a field name of a request body, which are in camel case.
+func isFieldName(word string) bool {
+	if word == "" || strings.ToLower(word[:1]) != word[:1] {
+		return false
+	}
+
+	for _, r := range word {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// `normalizeValidationPath` writes the indexes of a path between brackets, e.g. `components.1.data` becomes
+// `components[1].data`.
+func normalizeValidationPath(path string) string {
+	for indexSegment.MatchString(path) {
+		path = indexSegment.ReplaceAllString(path, "[$1]$2")
+	}
+
+	return path
+}
+
+// `SplitValidationPath` splits the path of a `ValidationError` into field names (strings) and list
+// indexes (ints), e.g. `validators[0].expected` becomes `["validators", 0, "expected"]`.
+// @param {string} path - The path of the field.
+// @returns {[]interface{}} - The steps of the path.
+func SplitValidationPath(path string) []interface{} {
+	result := make([]interface{}, 0)
+
+	for _, segment := range strings.Split(normalizeValidationPath(path), ".") {
+		name, rest, _ := strings.Cut(segment, "[")
+		if name != "" {
+			result = append(result, name)
+		}
+
+		for rest != "" {
+			var index string
+			index, rest, _ = strings.Cut(rest, "]")
+			rest = strings.TrimPrefix(rest, "[")
+
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				result = append(result, index)
+				continue
+			}
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"polycode-provider/client/models/content"
+	"polycode-provider/client/models/module"
+)
+
+func TestValidationErrors(t *testing.T) {
+	err := newAPIError(400, []byte(`{"errors":[{"path":"rootComponent.data.components[1].data.validators[0].expected","message":"must not be empty"}]}`))
+	expected := []ValidationError{{Path: "rootComponent.data.components[1].data.validators[0].expected", Message: "must not be empty"}}
+	if result := ValidationErrors(err); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	err = newAPIError(400, []byte(`{"statusCode":400,"message":["rootComponent.data.components.0.data.markdown must be a string","Invalid payload"],"error":"Bad Request"}`))
+	expected = []ValidationError{
+		{Path: "rootComponent.data.components[0].data.markdown", Message: "rootComponent.data.components.0.data.markdown must be a string"},
+		{Path: "", Message: "Invalid payload"},
+	}
+	if result := ValidationErrors(err); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if result := ValidationErrors(newAPIError(404, []byte(`{"errors":[{"path":"name"}]}`))); len(result) != 0 {
+		t.Errorf("Expected no validation errors for a not found error, got %v", result)
+	}
+}
+
+func TestSplitValidationPath(t *testing.T) {
+	expected := []interface{}{"rootComponent", "data", "components", 1, "data", "validators", 0, "expected", "stdout", 2}
+	if result := SplitValidationPath("rootComponent.data.components.1.data.validators[0].expected.stdout[2]"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	paths := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/module/validate" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"path":"name","message":"must be unique within the parent module"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := &Client{Host: server.URL, HTTPClient: server.Client()}
+
+	if err := c.ValidateContent(content.Content{Name: "Hello world"}); err != nil {
+		t.Errorf("Expected the content to be valid, got %s", err)
+	}
+	if err := c.ValidateContent(content.Content{ID: "c1", Name: "Hello world"}); err != nil {
+		t.Errorf("Expected the content to be valid, got %s", err)
+	}
+
+	err := c.ValidateModule(module.Module{Name: "Basics", Modules: []module.ModuleIdentifier{}, Contents: []module.ContentIdentifier{}})
+	if errs := ValidationErrors(err); len(errs) != 1 || errs[0].Path != "name" {
+		t.Errorf("Expected the module name to be rejected, got %v", err)
+	}
+
+	expected := []string{"POST /content/validate", "POST /content/c1/validate", "POST /module/validate"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	pc "polycode-provider/client"
+	polycode "polycode-provider/provider"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// `exportedResource` is a live object read through the provider and exported as a resource.
+// @property {string} Type - The Terraform resource type, e.g. `polycode_module`.
+// @property {string} Name - The unique Terraform name of the resource.
+// @property {ResourceData} Data - The state of the resource, read from the API.
+type exportedResource struct {
+	Type string
+	Name string
+	Data *schema.ResourceData
+}
+
+// `address` returns the Terraform address of the resource.
+func (r *exportedResource) address() string {
+	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+}
+
+// `exporter` walks the module tree and collects the resources to export.
+// The resources are read with the provider read functions so that the exported configuration
+// matches exactly what the provider would read after the import.
+type exporter struct {
+	ctx       context.Context
+	client    *pc.Client
+	provider  *schema.Provider
+	resources []*exportedResource
+	byID      map[string]*exportedResource
+	names     map[string]bool
+}
+
+func newExporter(ctx context.Context, c *pc.Client) *exporter {
+	return &exporter{
+		ctx:      ctx,
+		client:   c,
+		provider: polycode.Provider(),
+		byID:     make(map[string]*exportedResource),
+		names:    make(map[string]bool),
+	}
+}
+
+// `lookup` returns the exported resource of the given type and ID, nil if it was not exported.
+func (e *exporter) lookup(resourceType string, ID string) *exportedResource {
+	return e.byID[resourceType+"/"+ID]
+}
+
+// `read` reads the resource of the given type and ID through the provider and records it,
+// the caller names the resource once it is read.
+// @returns {*exportedResource} - The recorded resource.
+// @returns {bool} - Whether the resource was already recorded.
+// @returns {error} - An error if the resource could not be read.
+func (e *exporter) read(resourceType string, ID string) (*exportedResource, bool, error) {
+	if r := e.lookup(resourceType, ID); r != nil {
+		return r, true, nil
+	}
+
+	resource := e.provider.ResourcesMap[resourceType]
+
+	d := resource.Data(nil)
+	d.SetId(ID)
+
+	diags := resource.ReadContext(e.ctx, d, e.client)
+	for _, diagnostic := range diags {
+		if diagnostic.Severity == diag.Error {
+			return nil, false, fmt.Errorf("unable to read %s %s: %s: %s", resourceType, ID, diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	if d.Id() == "" {
+		return nil, false, fmt.Errorf("%s %s does not exist", resourceType, ID)
+	}
+
+	r := &exportedResource{
+		Type: resourceType,
+		Data: d,
+	}
+	e.resources = append(e.resources, r)
+	e.byID[resourceType+"/"+ID] = r
+
+	return r, false, nil
+}
+
+// `exportModule` exports a module along with its submodules and contents.
+func (e *exporter) exportModule(ID string) error {
+	module, seen, err := e.read("polycode_module", ID)
+	if err != nil || seen {
+		return err
+	}
+	module.Name = e.uniqueName(module.Type, module.Data.Get("name").(string))
+
+	for _, submoduleID := range module.Data.Get("module").([]interface{}) {
+		err = e.exportModule(submoduleID.(string))
+		if err != nil {
+			return err
+		}
+	}
+	for _, contentID := range module.Data.Get("content").([]interface{}) {
+		err = e.exportContent(contentID.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// `exportContent` exports a content along with the items used as hints by its editors.
+func (e *exporter) exportContent(ID string) error {
+	content, seen, err := e.read("polycode_content", ID)
+	if err != nil || seen {
+		return err
+	}
+	content.Name = e.uniqueName(content.Type, content.Data.Get("name").(string))
+
+	for _, itemID := range collectHints(content.Data.Get("container").([]interface{})) {
+		err = e.exportItem(itemID, content.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// `exportItem` exports an item, named after the content using it.
+func (e *exporter) exportItem(ID string, contentName string) error {
+	item, seen, err := e.read("polycode_item", ID)
+	if err != nil || seen {
+		return err
+	}
+
+	name := contentName
+	for _, itemType := range itemTypes(e.provider) {
+		if len(item.Data.Get(itemType).([]interface{})) > 0 {
+			name = fmt.Sprintf("%s_%s", contentName, itemType)
+			break
+		}
+	}
+	item.Name = e.uniqueName(item.Type, name)
+
+	return nil
+}
+
+// `itemTypes` returns the data blocks of `polycode_item`, exactly one of them is set and names the item type.
+func itemTypes(provider *schema.Provider) []string {
+	for _, attribute := range provider.ResourcesMap["polycode_item"].Schema {
+		if len(attribute.ExactlyOneOf) > 0 {
+			return attribute.ExactlyOneOf
+		}
+	}
+
+	return nil
+}
+
+// `collectHints` returns the IDs of the hint items of the editors held by nested containers.
+func collectHints(containers []interface{}) []string {
+	result := make([]string, 0)
+
+	for _, container := range containers {
+		c := container.(map[string]interface{})
+
+		if editors, ok := c["editor"].([]interface{}); ok {
+			for _, editor := range editors {
+				for _, hint := range editor.(map[string]interface{})["hint"].([]interface{}) {
+					result = append(result, hint.(string))
+				}
+			}
+		}
+		if nested, ok := c["container"].([]interface{}); ok {
+			result = append(result, collectHints(nested)...)
+		}
+	}
+
+	return result
+}
+
+var nonIdentifierCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// `uniqueName` converts a name into a Terraform identifier not used yet by a resource of the same type.
+func (e *exporter) uniqueName(resourceType string, name string) string {
+	base := strings.Trim(nonIdentifierCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" {
+		base = strings.TrimPrefix(resourceType, "polycode_")
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+
+	result := base
+	for i := 2; e.names[resourceType+"."+result]; i++ {
+		result = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[resourceType+"."+result] = true
+
+	return result
+}
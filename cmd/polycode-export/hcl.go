@@ -0,0 +1,349 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// `references` lists, by resource type, the attributes holding the ID of another resource,
+// along with the type of the referenced resource.
+var references = map[string]map[string]string{
+	"polycode_module": {
+		"module":  "polycode_module",
+		"content": "polycode_content",
+	},
+	"polycode_content": {
+		"hint": "polycode_item",
+	},
+	"polycode_item": {
+		"content_id": "polycode_content",
+	},
+}
+
+// `resourceFiles` is the file in which each resource type is written.
+var resourceFiles = []struct {
+	Type string
+	File string
+}{
+	{"polycode_module", "modules.tf"},
+	{"polycode_content", "contents.tf"},
+	{"polycode_item", "items.tf"},
+}
+
+// `renderedFile` is a generated configuration file.
+// @property {string} Name - The name of the file.
+// @property {[]byte} Content - The formatted HCL of the file.
+type renderedFile struct {
+	Name    string
+	Content []byte
+}
+
+// `render` generates the configuration of the exported resources, one file per resource type,
+// and an `imports.tf` file with the import blocks of every resource.
+// @returns {[]renderedFile} - The generated files, the files without resources are omitted.
+// @returns {error} - An error if an attribute could not be rendered.
+func (e *exporter) render() ([]renderedFile, error) {
+	result := make([]renderedFile, 0)
+
+	for _, resourceFile := range resourceFiles {
+		file := hclwrite.NewEmptyFile()
+
+		for _, r := range e.resources {
+			if r.Type != resourceFile.Type {
+				continue
+			}
+			if len(file.Body().Blocks()) > 0 {
+				file.Body().AppendNewline()
+			}
+
+			block := file.Body().AppendNewBlock("resource", []string{r.Type, r.Name})
+			resource := e.provider.ResourcesMap[r.Type]
+
+			values := make(map[string]interface{})
+			for key := range resource.Schema {
+				values[key] = r.Data.Get(key)
+			}
+
+			err := e.writeBody(block.Body(), r, resource.Schema, values)
+			if err != nil {
+				return nil, fmt.Errorf("unable to render %s: %w", r.address(), err)
+			}
+		}
+
+		if len(file.Body().Blocks()) > 0 {
+			result = append(result, renderedFile{Name: resourceFile.File, Content: hclwrite.Format(file.Bytes())})
+		}
+	}
+
+	imports := hclwrite.NewEmptyFile()
+	for i, r := range e.resources {
+		if i > 0 {
+			imports.Body().AppendNewline()
+		}
+
+		block := imports.Body().AppendNewBlock("import", nil)
+		block.Body().SetAttributeTraversal("to", traversal(r.Type, r.Name))
+		block.Body().SetAttributeValue("id", cty.StringVal(r.Data.Id()))
+	}
+	if len(e.resources) > 0 {
+		result = append(result, renderedFile{Name: "imports.tf", Content: hclwrite.Format(imports.Bytes())})
+	}
+
+	return result, nil
+}
+
+// `writeBody` writes the configurable attributes of a resource or of a nested block into a body.
+// Attributes are written before nested blocks, each sorted by name, and optional attributes left
+// to their default value are omitted, unless they are computed by the API.
+func (e *exporter) writeBody(body *hclwrite.Body, r *exportedResource, s map[string]*schema.Schema, values map[string]interface{}) error {
+	keys := make([]string, 0, len(s))
+	for key, attribute := range s {
+		if attribute.Computed && !attribute.Optional && !attribute.Required {
+			continue
+		}
+		if attribute.Deprecated != "" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	blocks := make([]string, 0)
+	for _, key := range keys {
+		attribute := s[key]
+
+		if _, ok := attribute.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+			continue
+		}
+		if !attribute.Required && !attribute.Computed && isDefault(attribute, values[key]) {
+			continue
+		}
+
+		tokens, err := e.valueTokens(r, key, values[key])
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		body.SetAttributeRaw(key, tokens)
+	}
+
+	for _, key := range blocks {
+		elem := s[key].Elem.(*schema.Resource)
+
+		for _, value := range listValue(values[key]) {
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			block := body.AppendNewBlock(key, nil)
+			err := e.writeBody(block.Body(), r, elem.Schema, nested)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// `valueTokens` returns the HCL tokens of an attribute value of a resource.
+// IDs of exported resources are replaced with references to these resources, except when the referenced
+// resource refers back to the resource and was exported first, e.g. the solution reveal item of a content
+// used as a hint by the content, so that the configuration has no dependency cycle.
+func (e *exporter) valueTokens(r *exportedResource, key string, value interface{}) (hclwrite.Tokens, error) {
+	switch v := value.(type) {
+	case string:
+		if targetType, ok := references[r.Type][key]; ok {
+			if target := e.lookup(targetType, v); target != nil && !(e.refersTo(target, r) && e.exportedBefore(target, r)) {
+				return hclwrite.TokensForTraversal(traversal(target.Type, target.Name, "id")), nil
+			}
+		}
+		return stringTokens(v), nil
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v))), nil
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v)), nil
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v)), nil
+	case []interface{}, *schema.Set:
+		elems := make([]hclwrite.Tokens, 0)
+		for _, elem := range listValue(v) {
+			tokens, err := e.valueTokens(r, key, elem)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, tokens)
+		}
+		return hclwrite.TokensForTuple(elems), nil
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		attrs := make([]hclwrite.ObjectAttrTokens, 0)
+		for _, name := range names {
+			tokens, err := e.valueTokens(r, key, v[name])
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(name)),
+				Value: tokens,
+			})
+		}
+		return hclwrite.TokensForObject(attrs), nil
+	}
+
+	return nil, fmt.Errorf("unsupported value of type %T", value)
+}
+
+// `refersTo` tells whether an attribute of a resource, or of its nested blocks, holds the ID of another resource.
+func (e *exporter) refersTo(r *exportedResource, target *exportedResource) bool {
+	values := make(map[string]interface{})
+	for key := range e.provider.ResourcesMap[r.Type].Schema {
+		values[key] = r.Data.Get(key)
+	}
+
+	return holdsReference(r.Type, "", values, target)
+}
+
+// `holdsReference` tells whether the value of an attribute, or the nested values of a block, holds the ID of
+// the target resource in an attribute referencing resources of its type.
+func holdsReference(resourceType string, key string, value interface{}, target *exportedResource) bool {
+	switch v := value.(type) {
+	case string:
+		return references[resourceType][key] == target.Type && v == target.Data.Id()
+	case []interface{}, *schema.Set:
+		for _, elem := range listValue(v) {
+			if holdsReference(resourceType, key, elem, target) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for name, elem := range v {
+			if holdsReference(resourceType, name, elem, target) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// `exportedBefore` tells whether a resource was exported before another one.
+func (e *exporter) exportedBefore(r *exportedResource, other *exportedResource) bool {
+	for _, exported := range e.resources {
+		switch exported {
+		case r:
+			return true
+		case other:
+			return false
+		}
+	}
+
+	return false
+}
+
+// `stringTokens` returns the tokens of a string, as a heredoc when it is a multiline text
+// ending with a newline, so that markdown and code stay readable.
+func stringTokens(value string) hclwrite.Tokens {
+	if strings.Count(value, "\n") < 2 || !strings.HasSuffix(value, "\n") {
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	}
+
+	delimiter := "EOT"
+	for strings.Contains(value, delimiter) {
+		delimiter += "_"
+	}
+
+	escaped := strings.ReplaceAll(value, "${", "$${")
+	escaped = strings.ReplaceAll(escaped, "%{", "%%{")
+
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + delimiter + "\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(escaped)},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(delimiter)},
+	}
+}
+
+// `traversal` builds the traversal of a reference, e.g. `polycode_module.name.id`.
+func traversal(root string, attributes ...string) hcl.Traversal {
+	result := hcl.Traversal{hcl.TraverseRoot{Name: root}}
+	for _, attribute := range attributes {
+		result = append(result, hcl.TraverseAttr{Name: attribute})
+	}
+
+	return result
+}
+
+// `listValue` returns the elements of a list or set value.
+func listValue(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+
+	return nil
+}
+
+// `isDefault` tells whether an optional attribute holds its default value, or its zero value
+// when it has no default.
+func isDefault(attribute *schema.Schema, value interface{}) bool {
+	if attribute.Default != nil {
+		return value == attribute.Default
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// `writeFile` writes a generated file into a directory.
+// @returns {string} - The path of the written file.
+// @returns {error} - An error if the file could not be written.
+func writeFile(dir string, file renderedFile) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, file.Name)
+
+	err = os.WriteFile(path, file.Content, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
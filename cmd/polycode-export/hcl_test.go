@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	e := newExporter(context.Background(), nil)
+
+	add := func(resourceType string, ID string, name string, values map[string]interface{}) {
+		d := e.provider.ResourcesMap[resourceType].Data(nil)
+		d.SetId(ID)
+		for key, value := range values {
+			err := d.Set(key, value)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r := &exportedResource{Type: resourceType, Name: e.uniqueName(resourceType, name), Data: d}
+		e.resources = append(e.resources, r)
+		e.byID[resourceType+"/"+ID] = r
+	}
+
+	add("polycode_module", "m1", "Intro to Go", map[string]interface{}{
+		"name":        "Intro to Go",
+		"description": "Basics",
+		"type":        "practice",
+		"content":     []interface{}{"c1", "unknown"},
+	})
+	add("polycode_content", "c1", "Hello, World!", map[string]interface{}{
+		"name":        "Hello, World!",
+		"description": "Print a greeting",
+		"type":        "exercise",
+		"container": []interface{}{map[string]interface{}{
+			"position":    0,
+			"orientation": "horizontal",
+			"markdown": []interface{}{map[string]interface{}{
+				"position": 0,
+				"content":  "# Hello\n\nPrint ${name}\n",
+			}},
+			"editor": []interface{}{map[string]interface{}{
+				"position": 1,
+				"hint":     []interface{}{"i1"},
+				"language_settings": []interface{}{map[string]interface{}{
+					"language": "PYTHON",
+				}},
+			}},
+		}},
+	})
+	add("polycode_item", "i1", "hello_world_hint", map[string]interface{}{
+		"cost": 10,
+		"hint": []interface{}{map[string]interface{}{"text": "Use print"}},
+	})
+
+	files, err := e.render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := make(map[string]string)
+	for _, file := range files {
+		rendered[file.Name] = string(file.Content)
+	}
+
+	expected := map[string][]string{
+		"modules.tf": {
+			`resource "polycode_module" "intro_to_go" {`,
+			`content     = [polycode_content.hello_world.id, "unknown"]`,
+		},
+		"contents.tf": {
+			`resource "polycode_content" "hello_world" {`,
+			`hint     = [polycode_item.hello_world_hint.id]`,
+			"content  = <<EOT\n# Hello\n\nPrint $${name}\nEOT",
+		},
+		"items.tf": {
+			`resource "polycode_item" "hello_world_hint" {`,
+			`text = "Use print"`,
+		},
+		"imports.tf": {
+			"to = polycode_module.intro_to_go",
+			`id = "i1"`,
+		},
+	}
+	for name, fragments := range expected {
+		for _, fragment := range fragments {
+			if !strings.Contains(rendered[name], fragment) {
+				t.Errorf("%s does not contain %q:\n%s", name, fragment, rendered[name])
+			}
+		}
+	}
+
+	if strings.Contains(rendered["modules.tf"], "ignore_external_members") {
+		t.Errorf("attributes left to their default value must be omitted:\n%s", rendered["modules.tf"])
+	}
+}
+
+func TestRenderBackReference(t *testing.T) {
+	e := newExporter(context.Background(), nil)
+
+	add := func(resourceType string, ID string, name string, values map[string]interface{}) {
+		d := e.provider.ResourcesMap[resourceType].Data(nil)
+		d.SetId(ID)
+		for key, value := range values {
+			err := d.Set(key, value)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r := &exportedResource{Type: resourceType, Name: e.uniqueName(resourceType, name), Data: d}
+		e.resources = append(e.resources, r)
+		e.byID[resourceType+"/"+ID] = r
+	}
+
+	add("polycode_content", "c1", "Hello, World!", map[string]interface{}{
+		"name":        "Hello, World!",
+		"description": "Print a greeting",
+		"type":        "exercise",
+		"container": []interface{}{map[string]interface{}{
+			"position":    0,
+			"orientation": "horizontal",
+			"editor": []interface{}{map[string]interface{}{
+				"position": 0,
+				"hint":     []interface{}{"i1"},
+				"language_settings": []interface{}{map[string]interface{}{
+					"language": "PYTHON",
+				}},
+			}},
+		}},
+	})
+	add("polycode_item", "i1", "hello_world_solution_reveal", map[string]interface{}{
+		"cost":            10,
+		"solution_reveal": []interface{}{map[string]interface{}{"content_id": "c1", "language": "PYTHON"}},
+	})
+	add("polycode_item", "i2", "other_solution_reveal", map[string]interface{}{
+		"cost":            10,
+		"solution_reveal": []interface{}{map[string]interface{}{"content_id": "c1", "language": "PYTHON"}},
+	})
+
+	files, err := e.render()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := make(map[string]string)
+	for _, file := range files {
+		rendered[file.Name] = string(file.Content)
+	}
+
+	if !strings.Contains(rendered["contents.tf"], `hint     = [polycode_item.hello_world_solution_reveal.id]`) {
+		t.Errorf("the content must reference its hint:\n%s", rendered["contents.tf"])
+	}
+	if !strings.Contains(rendered["items.tf"], `content_id = "c1"`) {
+		t.Errorf("the item referenced by its content must hold the literal ID of the content:\n%s", rendered["items.tf"])
+	}
+	if !strings.Contains(rendered["items.tf"], `content_id = polycode_content.hello_world.id`) {
+		t.Errorf("an item not referenced by its content must reference it:\n%s", rendered["items.tf"])
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	e := newExporter(context.Background(), nil)
+
+	names := []string{
+		e.uniqueName("polycode_module", "Intro to Go"),
+		e.uniqueName("polycode_module", "intro-to-go"),
+		e.uniqueName("polycode_module", "2048"),
+		e.uniqueName("polycode_module", "!!!"),
+		e.uniqueName("polycode_content", "Intro to Go"),
+	}
+	expected := []string{"intro_to_go", "intro_to_go_2", "_2048", "module", "intro_to_go"}
+
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], names[i])
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// `polycode-export` generates the Terraform configuration of Polycode modules created outside Terraform.
+// It walks the given modules with their submodules, contents and hint items, and writes the
+// matching resources along with the `import` blocks bringing them under Terraform management.
+//
+// Usage:
+//
+//	polycode-export [-host host] [-username username] [-password password] [-out directory] <module id>...
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	pc "polycode-provider/client"
+)
+
+func main() {
+	host := flag.String("host", os.Getenv("POLYCODE_HOST"), "The host of the Polycode API, defaults to POLYCODE_HOST")
+	username := flag.String("username", os.Getenv("POLYCODE_USERNAME"), "The Polycode username to connect with, defaults to POLYCODE_USERNAME")
+	password := flag.String("password", os.Getenv("POLYCODE_PASSWORD"), "The Polycode password to connect with, defaults to POLYCODE_PASSWORD")
+	out := flag.String("out", ".", "The directory where the .tf files are written")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <module id>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(*host, *username, *password, *out, flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "polycode-export: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// `run` exports the given modules into the output directory.
+func run(host string, username string, password string, out string, moduleIDs []string) error {
+	var hostPtr *string
+	if host != "" {
+		hostPtr = &host
+	}
+
+	var c *pc.Client
+	var err error
+	if username != "" && password != "" {
+		c, err = pc.NewClient(hostPtr, &username, &password)
+	} else {
+		c, err = pc.NewClient(hostPtr, nil, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to create Polycode client: %w", err)
+	}
+
+	e := newExporter(context.Background(), c)
+	for _, ID := range moduleIDs {
+		err = e.exportModule(ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := e.render()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		path, err := writeFile(out, file)
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// `polycode-lint` lints exercises before they are applied.
+// Each argument is either the directory of an exercise package or a JSON file holding the definition
+// of a root component, in the shape of `definition_json`. The command exits with status 1 when a
+// problem is found.
+//
+// Usage:
+//
+//	polycode-lint [-ignore rule,...] <directory or definition.json>...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"polycode-provider/client/exercise"
+	"polycode-provider/client/lint"
+	"polycode-provider/client/models/content"
+)
+
+func main() {
+	ignore := flag.String("ignore", "", fmt.Sprintf("Comma separated rules not to report, among %s", strings.Join(lint.Rules, ", ")))
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <directory or definition.json>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	options := lint.Options{Ignore: make([]string, 0)}
+	for _, rule := range strings.Split(*ignore, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if !lint.IsRule(rule) {
+			fmt.Fprintf(os.Stderr, "polycode-lint: unknown rule %q\n", rule)
+			os.Exit(2)
+		}
+		options.Ignore = append(options.Ignore, rule)
+	}
+
+	failed := false
+	for _, path := range flag.Args() {
+		findings, err := lintPath(path, options)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", path, err.Error())
+			failed = true
+			continue
+		}
+
+		for _, finding := range findings {
+			fmt.Printf("%s: %s\n", path, finding.String())
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// `lintPath` loads the exercise package or the definition file at the given path and lints it.
+func lintPath(path string, options lint.Options) ([]lint.Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		pkg, err := exercise.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		options.BaseDir = path
+		return lint.Content(pkg.Content, options), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rootComponent, err := content.ParseDefinition(string(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	options.BaseDir = filepath.Dir(path)
+	return lint.Content(content.Content{RootComponent: *rootComponent}, options), nil
+}
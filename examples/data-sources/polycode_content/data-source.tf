@@ -0,0 +1,7 @@
+data "polycode_content" "test_content" {
+  content_id = polycode_content.test_content.id
+}
+
+output "test_content_topics" {
+  value = data.polycode_content.test_content.topics
+}
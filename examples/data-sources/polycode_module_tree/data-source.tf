@@ -0,0 +1,8 @@
+data "polycode_module_tree" "test_tree" {
+  module_id       = polycode_module.test_module.id
+  max_concurrency = 8
+}
+
+output "test_tree_total_reward" {
+  value = data.polycode_module_tree.test_tree.total_reward
+}
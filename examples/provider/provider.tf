@@ -2,4 +2,18 @@ provider "polycode" {
   host     = "http://localhost:3000"
   username = "admin@gmail.com"
   password = "12345678"
+
+  # Shared submodules and contents are read once per refresh
+  cache_ttl = "30s"
+
+  # Stay under the rate limit of the API with a high -parallelism
+  requests_per_second     = 10
+  max_concurrent_requests = 4
+
+  # Let the API reject invalid contents and modules at plan time
+  plan_time_validation = true
+
+  default_tags {
+    tags = ["team:backend", "cohort:2026"]
+  }
 }
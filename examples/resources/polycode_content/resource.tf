@@ -4,6 +4,11 @@ resource "polycode_content" "test_content" {
   reward      = 100
   type        = "exercise"
 
+  difficulty          = "easy"
+  estimated_minutes   = 20
+  topics              = ["io", "strings"]
+  learning_objectives = ["Print to stdout"]
+
   container {
     orientation = "vertical"
     position    = 0
@@ -39,3 +44,44 @@ resource "polycode_content" "test_content" {
     }
   }
 }
+
+# The content can also be built from an exercise package directory:
+#
+#   hello_world/
+#     polycode.yaml       # reward, layout, languages and hidden_tests
+#     statement.md
+#     starter/python.py   # default code of each language
+#     solution/python.py
+#     tests/01.in
+#     tests/01.out
+resource "polycode_content" "hello_world" {
+  name        = "Hello world"
+  description = "Print a greeting"
+  type        = "exercise"
+  source_dir  = "${path.module}/exercises/hello_world"
+}
+
+# Or from a JSON definition, in the shape of the rootComponent of the API
+resource "polycode_content" "generated" {
+  name        = "Generated content"
+  description = "This content is produced by a generator"
+  reward      = 10
+  type        = "exercise"
+
+  definition_json = jsonencode({
+    type = "container"
+    data = {
+      orientation = "horizontal"
+      components = [
+        { type = "markdown", data = { markdown = "# Print 42" } },
+        {
+          type = "editor"
+          data = {
+            editorSettings = { languages = [{ language = "PYTHON", defaultCode = "", version = "" }] }
+            validators     = [{ isHidden = false, input = { stdin = [] }, expected = { stdout = ["42"] } }]
+          }
+        },
+      ]
+    }
+  })
+}
@@ -4,3 +4,10 @@ resource "polycode_item" "test_item" {
     text = "This is a hint"
   }
 }
+
+resource "polycode_item" "test_time_extension" {
+  cost = 50
+  time_extension {
+    minutes = 15
+  }
+}
@@ -0,0 +1 @@
+terraform import polycode_module.test_module 5d1c2e
@@ -0,0 +1,16 @@
+resource "polycode_module" "test_module" {
+  name              = "Test module"
+  description       = "This is a test module"
+  type              = "challenge"
+  reward            = 100
+  tags              = ["test"]
+  difficulty        = "easy"
+  estimated_minutes = 45
+
+  content = [polycode_content.test_content.id]
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+  }
+}
@@ -0,0 +1 @@
+terraform import polycode_module_content_attachment.test_attachment 0983b2/a7c1f4
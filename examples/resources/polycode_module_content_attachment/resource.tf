@@ -0,0 +1,14 @@
+resource "polycode_module" "test_module" {
+  name        = "Test module"
+  description = "This is a test module"
+  type        = "challenge"
+  reward      = 100
+  tags        = ["test"]
+
+  ignore_external_members = true
+}
+
+resource "polycode_module_content_attachment" "test_attachment" {
+  module_id  = polycode_module.test_module.id
+  content_id = polycode_content.test_content.id
+}
@@ -0,0 +1 @@
+terraform import polycode_module_submodule_attachment.test_attachment 0983b2/5d21e9
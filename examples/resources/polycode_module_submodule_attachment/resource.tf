@@ -0,0 +1,12 @@
+resource "polycode_module" "test_submodule" {
+  name        = "Test submodule"
+  description = "This is a test submodule"
+  type        = "submodule"
+  reward      = 10
+  tags        = ["test"]
+}
+
+resource "polycode_module_submodule_attachment" "test_attachment" {
+  module_id    = polycode_module.test_module.id
+  submodule_id = polycode_module.test_submodule.id
+}
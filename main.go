@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	polycode "polycode-provider/provider"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -8,6 +11,18 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
+	shutdownTracing, err := polycode.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Unable to set up tracing: %s", err.Error())
+	}
+	defer func() {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Unable to flush traces: %s", err.Error())
+		}
+	}()
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return polycode.Provider()
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceContent() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceContentRead,
+		Schema: map[string]*schema.Schema{
+			"content_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the content",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content name",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content description",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content type",
+			},
+			"reward": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The content reward",
+			},
+			"difficulty": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content difficulty",
+			},
+			"estimated_minutes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The estimated time to complete the content, in minutes",
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The topic tags of the content",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"learning_objectives": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The learning objectives of the content",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceContentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	contentID := d.Get("content_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading Content %s", contentID))
+
+	content, err := c.GetContent(contentID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get Content",
+			Detail:   fmt.Sprintf("Error when getting Content: %s", err.Error()),
+		})
+		return diags
+	}
+
+	values := deserializeContentData(content.Data)
+	values["name"] = content.Name
+	values["description"] = content.Description
+	values["type"] = content.Type
+	values["reward"] = content.Reward
+
+	for key, val := range values {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
+
+	d.SetId(content.ID)
+
+	return diags
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceModuleTree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceModuleTreeRead,
+		Schema: map[string]*schema.Schema{
+			"module_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the root module of the tree",
+			},
+			"max_concurrency": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     pc.DefaultTreeConcurrency,
+				Description: "The maximum number of requests sent in parallel to resolve the tree",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(int) <= 0 {
+						return nil, []error{fmt.Errorf("max_concurrency must be a strictly positive integer")}
+					}
+					return nil, nil
+				},
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The modules and contents of the tree, in depth first order",
+				Elem:        dataSourceModuleTreeNode(),
+			},
+			"total_reward": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The sum of the rewards of every module and content of the tree",
+			},
+			"content_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of contents of the tree",
+			},
+			"editor_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of editor components in the contents of the tree",
+			},
+			"total_hint_cost": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The sum of the costs of the hints referenced by the editors of the tree, counted once per reference",
+			},
+		},
+	}
+}
+
+func dataSourceModuleTreeNode() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the module or the content",
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kind of node, either module or content",
+			},
+			"parent_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the parent module, empty for the root module",
+			},
+			"depth": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The depth of the node, 0 for the root module",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the module or the content",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the module or the content",
+			},
+			"reward": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The reward of the module or the content",
+			},
+		},
+	}
+}
+
+func dataSourceModuleTreeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading Module tree %s", moduleID))
+
+	tree, err := c.GetModuleTree(moduleID, d.Get("max_concurrency").(int))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get Module tree",
+			Detail:   fmt.Sprintf("Error when getting Module tree: %s", err.Error()),
+		})
+		return diags
+	}
+
+	nodes := make([]interface{}, 0)
+	for _, node := range tree.Nodes {
+		value := map[string]interface{}{
+			"id":        node.ID,
+			"kind":      node.Kind,
+			"parent_id": node.ParentID,
+			"depth":     node.Depth,
+		}
+
+		switch node.Kind {
+		case pc.ModuleTreeNodeModule:
+			value["name"] = node.Module.Name
+			value["type"] = node.Module.Type
+			value["reward"] = node.Module.Reward
+		case pc.ModuleTreeNodeContent:
+			value["name"] = node.Content.Name
+			value["type"] = node.Content.Type
+			value["reward"] = node.Content.Reward
+		}
+
+		nodes = append(nodes, value)
+	}
+
+	values := map[string]interface{}{
+		"nodes":           nodes,
+		"total_reward":    tree.TotalReward,
+		"content_count":   tree.ContentCount,
+		"editor_count":    tree.EditorCount,
+		"total_hint_cost": tree.TotalHintCost,
+	}
+	for key, val := range values {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
+
+	d.SetId(moduleID)
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// `operationStateRefreshFunc` returns the refresh function of an asynchronous operation of the API, reading its
+// status with `GetOperation` until it completes. The failure of the operation is returned as its `OperationError`.
+// @param {*pc.Client} c - The client of the resource operation.
+// @param {*pc.Operation} operation - The operation, as returned by the API when it accepted the request.
+// @returns {resource.StateRefreshFunc} - The refresh function, its state being the status of the operation.
+func operationStateRefreshFunc(c *pc.Client, operation *pc.Operation) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		if !operation.Done() {
+			next, err := c.GetOperation(operation.URL)
+			if err != nil {
+				return nil, "", err
+			}
+			if next.ID == "" {
+				next.ID = operation.ID
+			}
+			operation = next
+		}
+
+		if operation.Status == pc.OperationFailed {
+			if operation.Error == nil {
+				operation.Error = &pc.OperationError{OperationID: operation.ID, ResourceID: operation.ResourceID}
+			}
+			return operation, operation.Status, operation.Error
+		}
+
+		return operation, operation.Status, nil
+	}
+}
+
+// `waitForOperation` is the `OperationWaiter` of the provider, waiting for an asynchronous operation of the API
+// until it succeeds, within the deadline of the context, which is the timeout of the create or update of the resource.
+func waitForOperation(ctx context.Context, c *pc.Client, operation *pc.Operation) (*pc.Operation, error) {
+	timeout := pc.DefaultOperationTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	interval := c.OperationPollInterval
+	if interval <= 0 {
+		interval = pc.DefaultOperationPollInterval
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Waiting for operation %s", operation.ID))
+
+	conf := &resource.StateChangeConf{
+		Pending:    []string{pc.OperationPending, pc.OperationRunning},
+		Target:     []string{pc.OperationSucceeded},
+		Refresh:    operationStateRefreshFunc(c.WithContext(ctx), operation),
+		Timeout:    timeout,
+		MinTimeout: interval,
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*pc.Operation), nil
+}
+
+// `refreshFailedOperation` refreshes the state of a resource an asynchronous operation of the API failed on.
+// The API may keep the resource the operation created or updated before failing: the resource is then tracked by
+// its ID, so that Terraform marks it as tainted rather than losing it, and its state is read from the API rather
+// than taken from the configuration.
+// @param err - The error of the create or update of the resource.
+// @param read - The read function of the resource.
+// @returns {diag.Diagnostics} - The diagnostics of the read, empty if the error is not the failure of an operation
+// or if the API did not keep the resource.
+func refreshFailedOperation(ctx context.Context, d *schema.ResourceData, m interface{}, err error, read schema.ReadContextFunc) diag.Diagnostics {
+	var operationErr *pc.OperationError
+	if !errors.As(err, &operationErr) {
+		return nil
+	}
+
+	if d.Id() == "" {
+		if operationErr.ResourceID == "" {
+			return nil
+		}
+		d.SetId(operationErr.ResourceID)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Refreshing %s after the failure of operation %s", d.Id(), operationErr.OperationID))
+
+	return read(ctx, d, m)
+}
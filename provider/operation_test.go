@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestItemCreateWaitsForOperation(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /item":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"pending"}}`))
+		case "GET /operation/op1":
+			polls++
+			if polls < 2 {
+				_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"running"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op1","status":"succeeded","resourceId":"i1"}}`))
+		case "GET /item/i1":
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":10}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &pc.Client{Host: server.URL, HTTPClient: server.Client(), OperationPollInterval: time.Millisecond, OperationWaiter: waitForOperation}
+	r := resourceItem()
+
+	state := &terraform.InstanceState{}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cost": 10,
+		"hint": []interface{}{map[string]interface{}{"text": "Use print"}},
+	})
+
+	diff, err := r.SimpleDiff(context.Background(), state, config, c)
+	if err != nil {
+		t.Fatalf("Error planning item: %s", err)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, c)
+	if diags.HasError() {
+		t.Fatalf("Error creating item: %v", diags)
+	}
+
+	if polls != 2 {
+		t.Errorf("Expected the operation to be polled until it succeeded, got %d polls", polls)
+	}
+	if newState.ID != "i1" || newState.Attributes["etag"] != `"v1"` {
+		t.Errorf("Expected the item to be read from the ID of the operation, got %v", newState)
+	}
+}
+
+func TestWaitForFailedOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"op2","status":"failed","resourceId":"c2","error":{"code":"compilation_failed","message":"The default code does not compile"}}}`))
+	}))
+	defer server.Close()
+
+	c := &pc.Client{Host: server.URL, HTTPClient: server.Client()}
+
+	_, err := waitForOperation(context.Background(), c, &pc.Operation{ID: "op2", URL: "/operation/op2", Status: pc.OperationRunning})
+
+	var operationErr *pc.OperationError
+	if !errors.As(err, &operationErr) || operationErr.ResourceID != "c2" || operationErr.Code != "compilation_failed" {
+		t.Errorf("Expected the failure of the operation, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	polycode "polycode-provider/client"
 
@@ -12,7 +13,7 @@ import (
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -33,15 +34,85 @@ func Provider() *schema.Provider {
 				Description: "The Polycode password to connect with",
 				DefaultFunc: schema.EnvDefaultFunc("POLYCODE_PASSWORD", nil),
 			},
+			"cache_ttl": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "How long the items, contents and modules read from the API are cached by the provider (e.g. 30s), caching is disabled if not set",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					_, err := time.ParseDuration(i.(string))
+					if err != nil {
+						return nil, []error{fmt.Errorf("cache_ttl must be a duration such as 30s or 5m: %s", err.Error())}
+					}
+					return nil, nil
+				},
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "The maximum number of requests per second sent to the API, not limited if not set",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(float64) < 0 {
+						return nil, []error{fmt.Errorf("requests_per_second must not be negative")}
+					}
+					return nil, nil
+				},
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The maximum number of requests in flight to the API, not limited if not set",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(int) < 0 {
+						return nil, []error{fmt.Errorf("max_concurrent_requests must not be negative")}
+					}
+					return nil, nil
+				},
+			},
+			"plan_time_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether contents and modules are validated by the API when they are planned, so that the rules only the API enforces (e.g. reward caps, unique names) fail the plan rather than the apply",
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags merged into the tags of every module managed by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The tags every module must carry",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"polycode_content": resourceContent(),
-			"polycode_item":    resourceItem(),
-			"polycode_module":  resourceModule(),
+			"polycode_content":                     resourceContent(),
+			"polycode_item":                        resourceItem(),
+			"polycode_module":                      resourceModule(),
+			"polycode_module_content_attachment":   resourceModuleContentAttachment(),
+			"polycode_module_submodule_attachment": resourceModuleSubmoduleAttachment(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"polycode_content":     dataSourceContent(),
+			"polycode_module_tree": dataSourceModuleTree(),
 		},
-		DataSourcesMap:       map[string]*schema.Resource{},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		traceResource(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		traceResource(name, r)
+	}
+
+	return p
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -56,6 +127,23 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		host = &tempHost
 	}
 
+	defaultTags := make([]string, 0)
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		for _, tag := range v.([]interface{}) {
+			defaultTags = append(defaultTags, tag.(string))
+		}
+	}
+
+	cacheTTL := time.Duration(0)
+	if v, ok := d.GetOk("cache_ttl"); ok {
+		cacheTTL, _ = time.ParseDuration(v.(string))
+	}
+
+	requestsPerSecond := d.Get("requests_per_second").(float64)
+	maxConcurrentRequests := d.Get("max_concurrent_requests").(int)
+
+	planTimeValidation := d.Get("plan_time_validation").(bool)
+
 	var diags diag.Diagnostics
 
 	if (username != "") && (password != "") {
@@ -70,6 +158,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 			return nil, diags
 		}
 
+		c.DefaultTags = defaultTags
+		c.Cache = polycode.NewCache(cacheTTL)
+		c.Limiter = polycode.NewLimiter(requestsPerSecond, maxConcurrentRequests)
+		c.LogContext = ctx
+		c.PlanTimeValidation = planTimeValidation
+		c.OperationWaiter = waitForOperation
+
 		tflog.Debug(ctx, fmt.Sprintf("Authenticated client with user %s", username))
 
 		return c, diags
@@ -85,6 +180,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diags
 	}
 
+	c.DefaultTags = defaultTags
+	c.Cache = polycode.NewCache(cacheTTL)
+	c.Limiter = polycode.NewLimiter(requestsPerSecond, maxConcurrentRequests)
+	c.LogContext = ctx
+	c.PlanTimeValidation = planTimeValidation
+	c.OperationWaiter = waitForOperation
+
 	tflog.Debug(ctx, "Authenticated anonymous client")
 
 	return c, diags
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	pc "polycode-provider/client"
+	"polycode-provider/client/exercise"
+	"polycode-provider/client/lint"
 	"polycode-provider/client/models/content"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -18,11 +22,44 @@ func resourceContent() *schema.Resource {
 		ReadContext:   resourceContentRead,
 		UpdateContext: resourceContentUpdate,
 		DeleteContext: resourceContentDelete,
+		CustomizeDiff: customdiff.All(
+			resourceContentSourceDirDiff,
+			resourceContentDefinitionDiff,
+			resourceContentLintDiff,
+			resourceContentPlanValidationDiff,
+			computedOnUpdateDiff,
+		),
 		Schema: map[string]*schema.Schema{
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the resource returned by the API, used to detect changes made outside Terraform",
+			},
 			"last_update": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Last update of the resource",
+				Description: "Last update of the resource, as reported by the API",
+				Deprecated:  "Use updated_at instead",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of creation of the resource, as reported by the API",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of the last update of the resource, as reported by the API",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who created the resource",
+			},
+			"updated_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who last updated the resource",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -58,9 +95,11 @@ func resourceContent() *schema.Resource {
 				},
 			},
 			"reward": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "The content reward",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"reward", "source_dir"},
+				Description:  "The content reward, read from polycode.yaml when source_dir is set",
 				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
 					if i.(int) < 0 {
 						return nil, []error{fmt.Errorf("reward must be a positive integer")}
@@ -68,17 +107,98 @@ func resourceContent() *schema.Resource {
 					return nil, nil
 				},
 			},
+			"difficulty": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The content difficulty, one of easy, medium or hard",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(string) != content.DifficultyEasy && i.(string) != content.DifficultyMedium && i.(string) != content.DifficultyHard {
+						return nil, []error{fmt.Errorf("difficulty must be easy, medium or hard")}
+					}
+					return nil, nil
+				},
+			},
+			"estimated_minutes": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The estimated time to complete the content, in minutes",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(int) < 0 {
+						return nil, []error{fmt.Errorf("estimated_minutes must be a positive integer")}
+					}
+					return nil, nil
+				},
+			},
+			"topics": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The topic tags of the content",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"learning_objectives": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The learning objectives of the content",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"container": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"container", "source_dir", "definition_json"},
+				Description:  "The content component, built from source_dir or definition_json when one of them is set",
+				Elem:         resourceContentContainer(1),
+			},
+			"definition_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"container", "source_dir", "definition_json"},
+				Description:  "The root component as JSON, in the shape of the rootComponent of the create content request, instead of the container block",
+				StateFunc:    normalizeContentDefinition,
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					_, err := content.ParseDefinition(i.(string))
+					if err != nil {
+						return nil, []error{fmt.Errorf("invalid %s: %s", s, err.Error())}
+					}
+					return nil, nil
+				},
+			},
+			"source_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"container", "source_dir", "definition_json"},
+				Description:  "The directory of an exercise package (statement.md, starter/, solution/, tests/ and polycode.yaml) the content is built from, instead of the container block",
+			},
+			"lint_ignore": {
 				Type:        schema.TypeList,
-				MaxItems:    1,
-				Required:    true,
-				Description: "The content component",
-				Elem:        resourceContentContainer(1),
+				Optional:    true,
+				Description: "The exercise lint rules not to report for this content",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+						if !lint.IsRule(i.(string)) {
+							return nil, []error{fmt.Errorf("%s must be one of %s", s, strings.Join(lint.Rules, ", "))}
+						}
+						return nil, nil
+					},
+				},
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the files of source_dir, a change of the files updates the content",
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
 	}
 }
 
@@ -277,7 +397,7 @@ func resourceContentCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	var diags diag.Diagnostics
 
-	rootComponent, err := serializeRootComponent(d.Get("container.0").(map[string]interface{}), ctx)
+	co, sourceHash, err := serializeContent(d, ctx)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -287,17 +407,12 @@ func resourceContentCreate(ctx context.Context, d *schema.ResourceData, m interf
 		return diags
 	}
 
-	co := content.Content{
-		Name:          d.Get("name").(string),
-		Description:   d.Get("description").(string),
-		Type:          d.Get("type").(string),
-		Reward:        int64(d.Get("reward").(int)),
-		RootComponent: *rootComponent,
-		Data:          content.ContentData{},
-	}
-
-	createdContent, err := c.CreateContent(co)
+	createdContent, err := c.CreateContent(*co)
 	if err != nil {
+		diags = append(diags, refreshFailedOperation(ctx, d, m, err, resourceContentRead)...)
+		if validationDiags := validationDiagnostics(d, "Invalid content", err, contentAttributePath); len(validationDiags) > 0 {
+			return append(diags, validationDiags...)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create content",
@@ -308,9 +423,21 @@ func resourceContentCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(createdContent.ID)
 
+	err = d.Set("source_hash", sourceHash)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set source_hash",
+			Detail:   fmt.Sprintf("Error when setting source_hash: %s", err.Error()),
+		})
+		return diags
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Created Content %s", d.Id()))
 
-	return resourceContentRead(ctx, d, m)
+	diags = append(diags, lintContentDiagnostics(*co, contentLintOptions(d))...)
+
+	return append(diags, resourceContentRead(ctx, d, m)...)
 }
 
 func resourceContentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -330,6 +457,17 @@ func resourceContentRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diags
 	}
 
+	logCacheStats(ctx, c)
+
+	err = d.Set("etag", content.ETag)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set etag",
+			Detail:   fmt.Sprintf("Error when setting etag: %s", err.Error()),
+		})
+		return diags
+	}
 	err = d.Set("name", content.Name)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -366,6 +504,28 @@ func resourceContentRead(ctx context.Context, d *schema.ResourceData, m interfac
 		})
 		return diags
 	}
+	for key, val := range deserializeAudit(content.Audit) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
+	for key, val := range deserializeContentData(content.Data) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
 	err = d.Set("container", deserializeRootComponent(content.RootComponent, 0, ctx))
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -383,7 +543,7 @@ func resourceContentUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	c := m.(*pc.Client)
 
-	rootComponent, err := serializeRootComponent(d.Get("container.0").(map[string]interface{}), ctx)
+	co, sourceHash, err := serializeContent(d, ctx)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -393,39 +553,35 @@ func resourceContentUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diags
 	}
 
-	co := content.Content{
-		ID:            d.Id(),
-		Name:          d.Get("name").(string),
-		Description:   d.Get("description").(string),
-		Type:          d.Get("type").(string),
-		Reward:        int64(d.Get("reward").(int)),
-		RootComponent: *rootComponent,
-		Data:          content.ContentData{},
-	}
+	co.ID = d.Id()
+	etag, _ := d.GetChange("etag")
+	co.ETag = etag.(string)
 
-	_, err = c.UpdateContent(co)
+	_, err = c.UpdateContent(*co)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update content",
-			Detail:   fmt.Sprintf("Error when updating content: %s", err.Error()),
-		})
+		diags = append(diags, refreshFailedOperation(ctx, d, m, err, resourceContentRead)...)
+		if validationDiags := validationDiagnostics(d, "Invalid content", err, contentAttributePath); len(validationDiags) > 0 {
+			return append(diags, validationDiags...)
+		}
+		diags = append(diags, updateErrorDiagnostic("content", d.Id(), err))
 		return diags
 	}
 
-	err = d.Set("last_update", time.Now().Format(time.RFC850))
+	err = d.Set("source_hash", sourceHash)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to set last_update",
-			Detail:   fmt.Sprintf("Error when setting last_update: %s", err.Error()),
+			Summary:  "Unable to set source_hash",
+			Detail:   fmt.Sprintf("Error when setting source_hash: %s", err.Error()),
 		})
 		return diags
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Updated Content %s", d.Id()))
 
-	return resourceContentRead(ctx, d, m)
+	diags = append(diags, lintContentDiagnostics(*co, contentLintOptions(d))...)
+
+	return append(diags, resourceContentRead(ctx, d, m)...)
 }
 
 func resourceContentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -448,6 +604,262 @@ func resourceContentDelete(ctx context.Context, d *schema.ResourceData, m interf
 	return diags
 }
 
+// `resourceContentSourceDirDiff` loads the exercise package of source_dir at plan time, so that an invalid
+// package fails the plan, and plans an update of the content when the files of the package changed
+func resourceContentSourceDirDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("source_dir") {
+		err := d.SetNewComputed("source_hash")
+		if err != nil {
+			return err
+		}
+		err = d.SetNewComputed("reward")
+		if err != nil {
+			return err
+		}
+		return d.SetNewComputed("container")
+	}
+
+	sourceDir := d.Get("source_dir").(string)
+	if sourceDir == "" {
+		if d.Get("source_hash").(string) != "" {
+			return d.SetNew("source_hash", "")
+		}
+		return nil
+	}
+
+	pkg, err := exercise.Load(sourceDir)
+	if err != nil {
+		return fmt.Errorf("invalid exercise package %s: %w", sourceDir, err)
+	}
+
+	if d.Get("reward").(int) != int(pkg.Content.Reward) {
+		err = d.SetNew("reward", int(pkg.Content.Reward))
+		if err != nil {
+			return err
+		}
+	}
+	if d.Get("source_hash").(string) != pkg.Hash {
+		tflog.Debug(ctx, fmt.Sprintf("Exercise package %s changed, new hash %s", sourceDir, pkg.Hash))
+
+		err = d.SetNew("source_hash", pkg.Hash)
+		if err != nil {
+			return err
+		}
+		return d.SetNewComputed("container")
+	}
+
+	return nil
+}
+
+// `serializeContent` reads the attributes of the content and returns them as a content.Content struct,
+// along with the hash of the exercise package when source_dir is set
+func serializeContent(d resourceChangeGetter, ctx context.Context) (*content.Content, string, error) {
+	result := content.Content{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Type:        d.Get("type").(string),
+		Reward:      int64(d.Get("reward").(int)),
+		Data:        serializeContentData(d),
+	}
+
+	rootComponent, pkg, err := serializeContentRootComponent(d, ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	result.RootComponent = *rootComponent
+
+	if d.Get("definition_json").(string) == "" && pkg == nil {
+		return &result, "", nil
+	}
+
+	previous, _ := d.GetChange("container")
+	if containers := previous.([]interface{}); len(containers) > 0 && containers[0] != nil {
+		previousRootComponent, err := serializeRootComponent(containers[0].(map[string]interface{}), ctx)
+		if err == nil {
+			result.RootComponent.InheritIDs(*previousRootComponent)
+		}
+	}
+
+	if pkg == nil {
+		return &result, "", nil
+	}
+	result.Reward = pkg.Content.Reward
+
+	return &result, pkg.Hash, nil
+}
+
+// `resourceGetter` reads the attributes of a resource, it is implemented by both
+// schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
+// `resourceChangeGetter` also reads the previous value of the attributes of a resource
+type resourceChangeGetter interface {
+	resourceGetter
+	GetChange(key string) (interface{}, interface{})
+}
+
+// `serializeContentRootComponent` builds the root component of the content from definition_json, from the exercise
+// package of source_dir or from the container block, the exercise package is returned when source_dir is set
+func serializeContentRootComponent(d resourceGetter, ctx context.Context) (*content.Component, *exercise.Package, error) {
+	definition := d.Get("definition_json").(string)
+	sourceDir := d.Get("source_dir").(string)
+
+	switch {
+	case definition != "":
+		rootComponent, err := content.ParseDefinition(definition)
+		return rootComponent, nil, err
+	case sourceDir != "":
+		pkg, err := exercise.Load(sourceDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid exercise package %s: %w", sourceDir, err)
+		}
+		return &pkg.Content.RootComponent, pkg, nil
+	}
+
+	rootComponent, err := serializeRootComponent(d.Get("container.0").(map[string]interface{}), ctx)
+	return rootComponent, nil, err
+}
+
+// `resourceContentPlanValidationDiff` asks the API to validate the planned content when plan_time_validation is
+// enabled, so that the rules only the API knows about fail the plan rather than the apply
+func resourceContentPlanValidationDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c, ok := m.(*pc.Client)
+	if !ok || !c.PlanTimeValidation {
+		return nil
+	}
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	keys := []string{"name", "description", "type", "reward", "source_dir", "definition_json", "difficulty", "estimated_minutes", "topics", "learning_objectives"}
+	// The container is planned as unknown when it is built from source_dir or definition_json, it only has to be
+	// known when it is configured.
+	if newValuesKnown(d, "source_dir", "definition_json") && d.Get("source_dir").(string) == "" && d.Get("definition_json").(string) == "" {
+		keys = append(keys, "container")
+	}
+	if !newValuesKnown(d, keys...) {
+		tflog.Debug(ctx, "Skipping plan time validation of Content, some attributes are not known yet")
+		return nil
+	}
+
+	co, _, err := serializeContent(d, ctx)
+	if err != nil {
+		return fmt.Errorf("invalid content: %w", err)
+	}
+	co.ID = d.Id()
+
+	tflog.Debug(ctx, fmt.Sprintf("Validating Content %s with the API", d.Id()))
+
+	return planValidationError("content", d, c.ValidateContent(*co), contentAttributePath)
+}
+
+// `resourceContentLintDiff` lints the planned components of the content and logs the findings as warnings,
+// the plugin SDK does not let a CustomizeDiff return warnings so they are reported again on apply
+func resourceContentLintDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"source_dir", "definition_json", "lint_ignore"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	// The container is planned as unknown when it is built from source_dir or definition_json, it only has to be
+	// known when it is configured.
+	if d.Get("source_dir").(string) == "" && d.Get("definition_json").(string) == "" {
+		if !d.NewValueKnown("container") || len(d.Get("container").([]interface{})) == 0 {
+			return nil
+		}
+	}
+
+	rootComponent, _, err := serializeContentRootComponent(d, ctx)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping lint of content %s: %s", d.Get("name"), err.Error()))
+		return nil
+	}
+
+	for _, finding := range lint.Content(content.Content{RootComponent: *rootComponent}, contentLintOptions(d)) {
+		tflog.Warn(ctx, fmt.Sprintf("Content %s: %s", d.Get("name"), finding.String()))
+	}
+
+	return nil
+}
+
+// `contentLintOptions` reads the lint options of the content: the rules suppressed by lint_ignore and
+// the directory of the exercise package relative links are resolved from
+func contentLintOptions(d resourceGetter) lint.Options {
+	ignore := make([]string, 0)
+	for _, rule := range d.Get("lint_ignore").([]interface{}) {
+		ignore = append(ignore, rule.(string))
+	}
+
+	return lint.Options{
+		BaseDir: d.Get("source_dir").(string),
+		Ignore:  ignore,
+	}
+}
+
+// `lintContentDiagnostics` lints the components of the content and returns the findings as warnings
+func lintContentDiagnostics(co content.Content, options lint.Options) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, finding := range lint.Content(co, options) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Content %s: %s", co.Name, finding.Message),
+			Detail:   fmt.Sprintf("%s at %s, add %q to lint_ignore to suppress this warning", finding.Rule, finding.Path, finding.Rule),
+		})
+	}
+
+	return diags
+}
+
+// `resourceContentDefinitionDiff` plans the container read back from the API as unknown when definition_json changes
+func resourceContentDefinitionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("definition_json").(string) == "" || !d.HasChange("definition_json") {
+		return nil
+	}
+
+	return d.SetNewComputed("container")
+}
+
+// `normalizeContentDefinition` stores definition_json in its canonical form, so that formatting changes do not produce diffs
+func normalizeContentDefinition(i interface{}) string {
+	normalized, err := content.NormalizeDefinition(i.(string))
+	if err != nil {
+		return i.(string)
+	}
+
+	return normalized
+}
+
+// `serializeContentData` reads the content metadata attributes and returns them as a content.ContentData struct
+func serializeContentData(d resourceGetter) content.ContentData {
+	topics := make([]string, 0)
+	for _, v := range d.Get("topics").([]interface{}) {
+		topics = append(topics, v.(string))
+	}
+	learningObjectives := make([]string, 0)
+	for _, v := range d.Get("learning_objectives").([]interface{}) {
+		learningObjectives = append(learningObjectives, v.(string))
+	}
+
+	return content.ContentData{
+		Difficulty:         d.Get("difficulty").(string),
+		EstimatedMinutes:   int64(d.Get("estimated_minutes").(int)),
+		Topics:             topics,
+		LearningObjectives: learningObjectives,
+	}
+}
+
+// `deserializeContentData` takes a content.ContentData and converts it into the content metadata attributes
+func deserializeContentData(data content.ContentData) map[string]interface{} {
+	return map[string]interface{}{
+		"difficulty":          data.Difficulty,
+		"estimated_minutes":   data.EstimatedMinutes,
+		"topics":              data.Topics,
+		"learning_objectives": data.LearningObjectives,
+	}
+}
+
 // `serializeRootComponent` takes the schema of a root component and returns itself as content.Component struct
 func serializeRootComponent(rootComponent map[string]interface{}, ctx context.Context) (*content.Component, error) {
 	length := 0
@@ -13,17 +13,47 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// `itemDataKeys` are the item blocks, one per item type, exactly one of them must be set.
+var itemDataKeys = []string{"hint", "solution_reveal", "hidden_test_reveal", "time_extension", "retry_token"}
+
 func resourceItem() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceItemCreate,
 		ReadContext:   resourceItemRead,
 		UpdateContext: resourceItemUpdate,
 		DeleteContext: resourceItemDelete,
+		CustomizeDiff: computedOnUpdateDiff,
 		Schema: map[string]*schema.Schema{
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the resource returned by the API, used to detect changes made outside Terraform",
+			},
 			"last_update": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Last update of the resource",
+				Description: "Last update of the resource, as reported by the API",
+				Deprecated:  "Use updated_at instead",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of creation of the resource, as reported by the API",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of the last update of the resource, as reported by the API",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who created the resource",
+			},
+			"updated_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who last updated the resource",
 			},
 			"cost": {
 				Type:        schema.TypeInt,
@@ -37,16 +67,55 @@ func resourceItem() *schema.Resource {
 				},
 			},
 			"hint": {
-				Type:        schema.TypeList,
-				Required:    true,
-				MaxItems:    1,
-				Description: "The hint component",
-				Elem:        resourceItemDataHint(),
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The hint component",
+				Elem:         resourceItemDataHint(),
+				ExactlyOneOf: itemDataKeys,
+			},
+			"solution_reveal": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The solution reveal component",
+				Elem:         resourceItemDataSolutionReveal(),
+				ExactlyOneOf: itemDataKeys,
+			},
+			"hidden_test_reveal": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The hidden test reveal component",
+				Elem:         resourceItemDataHiddenTestReveal(),
+				ExactlyOneOf: itemDataKeys,
+			},
+			"time_extension": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The time extension component",
+				Elem:         resourceItemDataTimeExtension(),
+				ExactlyOneOf: itemDataKeys,
+			},
+			"retry_token": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The retry token component",
+				Elem:         resourceItemDataRetryToken(),
+				ExactlyOneOf: itemDataKeys,
 			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Minute),
+			Read:   schema.DefaultTimeout(time.Minute),
+			Update: schema.DefaultTimeout(time.Minute),
+			Delete: schema.DefaultTimeout(time.Minute),
+		},
 	}
 }
 
@@ -62,16 +131,92 @@ func resourceItemDataHint() *schema.Resource {
 	}
 }
 
+func resourceItemDataSolutionReveal() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"content_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the content whose solution is revealed",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The language of the revealed solution, every language if not set",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(string) != "PYTHON" && i.(string) != "NODE" && i.(string) != "JAVA" && i.(string) != "RUST" {
+						return nil, []error{fmt.Errorf("language must be one of PYTHON, NODE, JAVA or RUST")}
+					}
+					return nil, nil
+				},
+			},
+		},
+	}
+}
+
+func resourceItemDataHiddenTestReveal() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"content_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the content holding the hidden validator",
+			},
+			"validator_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the hidden validator to reveal",
+			},
+		},
+	}
+}
+
+func resourceItemDataTimeExtension() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"minutes": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The number of minutes added to the time limit",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(int) <= 0 {
+						return nil, []error{fmt.Errorf("minutes must be a strictly positive integer")}
+					}
+					return nil, nil
+				},
+			},
+		},
+	}
+}
+
+func resourceItemDataRetryToken() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"count": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The number of additional submissions granted",
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					if i.(int) <= 0 {
+						return nil, []error{fmt.Errorf("count must be a strictly positive integer")}
+					}
+					return nil, nil
+				},
+			},
+		},
+	}
+}
+
 func resourceItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*pc.Client)
 
 	var diags diag.Diagnostics
 
+	data := serializeItemData(d)
+
 	it := item.Item{
-		Type: "hint",
-		Data: item.ItemData{
-			Text: d.Get("hint.0.text").(string),
-		},
+		Type: data.Type(),
+		Data: data,
 		Cost: int64(d.Get("cost").(int)),
 	}
 
@@ -109,6 +254,17 @@ func resourceItemRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		return diags
 	}
 
+	logCacheStats(ctx, c)
+
+	err = d.Set("etag", item.ETag)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set etag",
+			Detail:   fmt.Sprintf("Error when setting etag: %s", err.Error()),
+		})
+		return diags
+	}
 	err = d.Set("cost", item.Cost)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -118,15 +274,36 @@ func resourceItemRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		})
 		return diags
 	}
-	err = d.Set("hint", []interface{}{map[string]interface{}{"text": item.Data.Text}})
-	if err != nil {
+	if item.Data.Type() == "" {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to set hint text",
-			Detail:   fmt.Sprintf("Error when setting hint text: %s", err.Error()),
+			Summary:  "Unsupported item type",
+			Detail:   fmt.Sprintf("Item %s has type %q which is not supported by the provider", d.Id(), item.Type),
 		})
 		return diags
 	}
+	for key, val := range deserializeAudit(item.Audit) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
+	for key, val := range deserializeItemData(item.Data) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+			return diags
+		}
+	}
 
 	return diags
 }
@@ -136,32 +313,12 @@ func resourceItemUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	c := m.(*pc.Client)
 
-	it := item.Item{
-		ID:   d.Id(),
-		Type: "hint",
-		Data: item.ItemData{
-			Text: d.Get("hint.0.text").(string),
-		},
-		Cost: int64(d.Get("cost").(int)),
-	}
+	// The etag is unknown in the plan of an update, see computedOnUpdateDiff, the one read last is the old value.
+	etag, _ := d.GetChange("etag")
 
-	_, err := c.UpdateItem(it)
+	_, err := c.PatchItem(d.Id(), serializeItemChanges(d), etag.(string))
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update item",
-			Detail:   fmt.Sprintf("Error when updating item: %s", err.Error()),
-		})
-		return diags
-	}
-
-	err = d.Set("last_update", time.Now().Format(time.RFC850))
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set last update",
-			Detail:   fmt.Sprintf("Error when setting last update: %s", err.Error()),
-		})
+		diags = append(diags, updateErrorDiagnostic("item", d.Id(), err))
 		return diags
 	}
 
@@ -189,3 +346,99 @@ func resourceItemDelete(ctx context.Context, d *schema.ResourceData, m interface
 
 	return diags
 }
+
+// `serializeItemData` reads the item block that is set and returns it as an item.ItemData struct
+func serializeItemData(d *schema.ResourceData) item.ItemData {
+	data := item.ItemData{}
+
+	if v, ok := d.GetOk("hint.0"); ok {
+		hint := v.(map[string]interface{})
+		data.Hint = &item.HintData{
+			Text: hint["text"].(string),
+		}
+	}
+	if v, ok := d.GetOk("solution_reveal.0"); ok {
+		solutionReveal := v.(map[string]interface{})
+		data.SolutionReveal = &item.SolutionRevealData{
+			ContentID: solutionReveal["content_id"].(string),
+			Language:  solutionReveal["language"].(string),
+		}
+	}
+	if v, ok := d.GetOk("hidden_test_reveal.0"); ok {
+		hiddenTestReveal := v.(map[string]interface{})
+		data.HiddenTestReveal = &item.HiddenTestRevealData{
+			ContentID:   hiddenTestReveal["content_id"].(string),
+			ValidatorID: hiddenTestReveal["validator_id"].(string),
+		}
+	}
+	if v, ok := d.GetOk("time_extension.0"); ok {
+		timeExtension := v.(map[string]interface{})
+		data.TimeExtension = &item.TimeExtensionData{
+			Minutes: int64(timeExtension["minutes"].(int)),
+		}
+	}
+	if v, ok := d.GetOk("retry_token.0"); ok {
+		retryToken := v.(map[string]interface{})
+		data.RetryToken = &item.RetryTokenData{
+			Count: int64(retryToken["count"].(int)),
+		}
+	}
+
+	return data
+}
+
+// `deserializeItemData` takes an item.ItemData and converts it into the value of every item block,
+// the blocks not matching the item type are emptied
+func deserializeItemData(data item.ItemData) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, key := range itemDataKeys {
+		result[key] = []interface{}{}
+	}
+
+	switch {
+	case data.Hint != nil:
+		result["hint"] = []interface{}{map[string]interface{}{
+			"text": data.Hint.Text,
+		}}
+	case data.SolutionReveal != nil:
+		result["solution_reveal"] = []interface{}{map[string]interface{}{
+			"content_id": data.SolutionReveal.ContentID,
+			"language":   data.SolutionReveal.Language,
+		}}
+	case data.HiddenTestReveal != nil:
+		result["hidden_test_reveal"] = []interface{}{map[string]interface{}{
+			"content_id":   data.HiddenTestReveal.ContentID,
+			"validator_id": data.HiddenTestReveal.ValidatorID,
+		}}
+	case data.TimeExtension != nil:
+		result["time_extension"] = []interface{}{map[string]interface{}{
+			"minutes": data.TimeExtension.Minutes,
+		}}
+	case data.RetryToken != nil:
+		result["retry_token"] = []interface{}{map[string]interface{}{
+			"count": data.RetryToken.Count,
+		}}
+	}
+
+	return result
+}
+
+// `serializeItemChanges` returns an update request holding only the item attributes that changed,
+// the type is sent along with the data when any item block changed
+func serializeItemChanges(d *schema.ResourceData) item.UpdateItemRequest {
+	request := item.UpdateItemRequest{}
+
+	if d.HasChange("cost") {
+		cost := int64(d.Get("cost").(int))
+		request.Cost = &cost
+	}
+	if d.HasChanges(itemDataKeys...) {
+		data := serializeItemData(d)
+		itemType := data.Type()
+		requestData := data.IntoCreateItemRequestData()
+		request.Type = &itemType
+		request.Data = &requestData
+	}
+
+	return request
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestItemUpdateSendsETag(t *testing.T) {
+	var ifMatch, patch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			ifMatch = r.Header.Get("If-Match")
+			body, _ := io.ReadAll(r.Body)
+			patch = string(body)
+		}
+		w.Header().Set("ETag", `"v2"`)
+		_, _ = w.Write([]byte(`{"metadata":{},"data":{"id":"i1","type":"hint","data":{"text":"Use print"},"cost":20}}`))
+	}))
+	defer server.Close()
+
+	c := &pc.Client{Host: server.URL, HTTPClient: server.Client()}
+	r := resourceItem()
+
+	state := &terraform.InstanceState{
+		ID: "i1",
+		Attributes: map[string]string{
+			"id":          "i1",
+			"etag":        `"v1"`,
+			"cost":        "10",
+			"hint.#":      "1",
+			"hint.0.text": "Use print",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cost": 20,
+		"hint": []interface{}{map[string]interface{}{"text": "Use print"}},
+	})
+
+	diff, err := r.SimpleDiff(context.Background(), state, config, c)
+	if err != nil {
+		t.Fatalf("Error planning item: %s", err)
+	}
+	if diff.Attributes["etag"] == nil || !diff.Attributes["etag"].NewComputed {
+		t.Fatalf("Expected the etag to be unknown in the plan, got %v", diff.Attributes["etag"])
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, c)
+	if diags.HasError() {
+		t.Fatalf("Error updating item: %v", diags)
+	}
+
+	if ifMatch != `"v1"` {
+		t.Errorf("Expected the etag of the state to be sent with If-Match, got %q", ifMatch)
+	}
+	if patch != `{"cost":20}` {
+		t.Errorf("Expected only the cost to be updated, got %s", patch)
+	}
+	if newState.Attributes["etag"] != `"v2"` {
+		t.Errorf("Expected the etag to be read again, got %q", newState.Attributes["etag"])
+	}
+}
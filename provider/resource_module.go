@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,11 +20,44 @@ func resourceModule() *schema.Resource {
 		ReadContext:   resourceModuleRead,
 		UpdateContext: resourceModuleUpdate,
 		DeleteContext: resourceModuleDelete,
+		CustomizeDiff: customdiff.All(
+			resourceModuleTagsAllDiff,
+			resourceModuleAvailabilityWindowDiff,
+			resourceModuleGraphDiff,
+			resourceModulePlanValidationDiff,
+			computedOnUpdateDiff,
+		),
 		Schema: map[string]*schema.Schema{
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the resource returned by the API, used to detect changes made outside Terraform",
+			},
 			"last_update": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Last update of the resource",
+				Description: "Last update of the resource, as reported by the API",
+				Deprecated:  "Use updated_at instead",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of creation of the resource, as reported by the API",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC3339 date of the last update of the resource, as reported by the API",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who created the resource",
+			},
+			"updated_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user who last updated the resource",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -67,6 +101,12 @@ func resourceModule() *schema.Resource {
 				Description: "Tags of the module",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"tags_all": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tags of the module, including the provider default tags",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"reward": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -79,29 +119,153 @@ func resourceModule() *schema.Resource {
 					return
 				},
 			},
+			"visibility": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Visibility of the module, one of draft, published or archived",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if v != module.VisibilityDraft && v != module.VisibilityPublished && v != module.VisibilityArchived {
+						errs = append(errs, fmt.Errorf("%q must be draft, published or archived", key))
+					}
+					return
+				},
+			},
+			"difficulty": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Difficulty of the module, one of easy, medium or hard",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if v != module.DifficultyEasy && v != module.DifficultyMedium && v != module.DifficultyHard {
+						errs = append(errs, fmt.Errorf("%q must be easy, medium or hard", key))
+					}
+					return
+				},
+			},
+			"estimated_minutes": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Estimated time to complete the module, in minutes",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q must be positive", key))
+					}
+					return
+				},
+			},
+			"starts_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "RFC3339 date from which the module is available",
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+			},
+			"ends_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "RFC3339 date until which the module is available",
+				ValidateFunc:     validateRFC3339,
+				DiffSuppressFunc: suppressEquivalentRFC3339,
+			},
 			"module": {
 				Type:        schema.TypeList,
-				Required:    true,
+				Optional:    true,
 				Description: "List of modules id",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"content": {
 				Type:        schema.TypeList,
-				Required:    true,
+				Optional:    true,
 				Description: "List of content id",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"ignore_external_members": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether modules and contents attached outside of this resource (e.g. with polycode_module_content_attachment) are ignored instead of being removed",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+			Read:   schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
+			Delete: schema.DefaultTimeout(2 * time.Minute),
+		},
 	}
 }
 
-func resourceModuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// `resourceModuleTagsAllDiff` plans the tags of the module merged with the provider default tags,
+// so that a change of the default tags updates the module without changing its tags attribute
+func resourceModuleTagsAllDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
 	c := m.(*pc.Client)
 
-	var diags diag.Diagnostics
+	tags := make([]string, 0)
+	for _, v := range d.Get("tags").([]interface{}) {
+		tags = append(tags, v.(string))
+	}
+	tagsAll := module.MergeTags(tags, c.DefaultTags)
+
+	current := make([]string, 0)
+	for _, v := range d.Get("tags_all").([]interface{}) {
+		current = append(current, v.(string))
+	}
+	if d.Id() != "" && equalStrings(current, tagsAll) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// `resourceModuleAvailabilityWindowDiff` checks that the planned availability window ends after it starts
+func resourceModuleAvailabilityWindowDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("starts_at") || !d.NewValueKnown("ends_at") {
+		return nil
+	}
+
+	startsAt := d.Get("starts_at").(string)
+	endsAt := d.Get("ends_at").(string)
+	if startsAt == "" || endsAt == "" {
+		return nil
+	}
+
+	start, err := time.Parse(time.RFC3339, startsAt)
+	if err != nil {
+		return fmt.Errorf("starts_at must be a RFC3339 date: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, endsAt)
+	if err != nil {
+		return fmt.Errorf("ends_at must be a RFC3339 date: %w", err)
+	}
+
+	if !end.After(start) {
+		return fmt.Errorf("ends_at (%s) must be after starts_at (%s)", endsAt, startsAt)
+	}
+
+	return nil
+}
+
+// `resourceModuleGraphDiff` validates the planned module dependency graph against the modules in the API
+func resourceModuleGraphDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("module") && !d.HasChange("type") {
+		return nil
+	}
+	if !d.NewValueKnown("module") || !d.NewValueKnown("type") {
+		tflog.Debug(ctx, "Skipping module graph validation, submodules are not known yet")
+		return nil
+	}
+
+	c := m.(*pc.Client)
 
 	modules := make([]module.ModuleIdentifier, 0)
 	for _, v := range d.Get("module").([]interface{}) {
@@ -109,29 +273,59 @@ func resourceModuleCreate(ctx context.Context, d *schema.ResourceData, m interfa
 			ID: v.(string),
 		})
 	}
-	contents := make([]module.ContentIdentifier, 0)
-	for _, v := range d.Get("content").([]interface{}) {
-		contents = append(contents, module.ContentIdentifier{
-			ID: v.(string),
-		})
+
+	mo := module.Module{
+		ID:      d.Id(),
+		Type:    d.Get("type").(string),
+		Modules: modules,
 	}
-	tags := make([]string, 0)
-	for _, v := range d.Get("tags").([]interface{}) {
-		tags = append(tags, v.(string))
+
+	tflog.Debug(ctx, fmt.Sprintf("Validating module graph of Module %s", d.Id()))
+
+	err := c.ValidateModuleGraph(mo)
+	if err != nil {
+		return fmt.Errorf("invalid module dependency graph: %w", err)
 	}
 
-	mo := module.Module{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		Type:        d.Get("type").(string),
-		Tags:        tags,
-		Reward:      int64(d.Get("reward").(int)),
-		Modules:     modules,
-		Contents:    contents,
+	return nil
+}
+
+// `resourceModulePlanValidationDiff` asks the API to validate the planned module when plan_time_validation is
+// enabled, so that the rules only the API knows about, such as unique names, fail the plan rather than the apply
+func resourceModulePlanValidationDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c, ok := m.(*pc.Client)
+	if !ok || !c.PlanTimeValidation {
+		return nil
+	}
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if !newValuesKnown(d, "name", "description", "type", "reward", "tags", "module", "content", "visibility", "difficulty", "estimated_minutes", "starts_at", "ends_at") {
+		tflog.Debug(ctx, "Skipping plan time validation of Module, some attributes are not known yet")
+		return nil
 	}
 
+	mo := serializeModule(d, c.DefaultTags)
+	mo.ID = d.Id()
+
+	tflog.Debug(ctx, fmt.Sprintf("Validating Module %s with the API", d.Id()))
+
+	return planValidationError("module", d, c.ValidateModule(mo), moduleAttributePath)
+}
+
+func resourceModuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	mo := serializeModule(d, c.DefaultTags)
+
 	createdModule, err := c.CreateModule(mo)
 	if err != nil {
+		diags = append(diags, refreshFailedOperation(ctx, d, m, err, resourceModuleRead)...)
+		if validationDiags := validationDiagnostics(d, "Invalid module", err, moduleAttributePath); len(validationDiags) > 0 {
+			return append(diags, validationDiags...)
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create module",
@@ -164,6 +358,8 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
+	logCacheStats(ctx, c)
+
 	modules := make([]string, 0)
 	for _, v := range module.Modules {
 		modules = append(modules, v.ID)
@@ -172,7 +368,20 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface
 	for _, v := range module.Contents {
 		contents = append(contents, v.ID)
 	}
+	// An imported module has only its ID in its state, none of its members are managed yet so all of them are kept
+	if d.Get("ignore_external_members").(bool) && d.Get("type").(string) != "" {
+		modules = keepManagedMembers(modules, d.Get("module").([]interface{}))
+		contents = keepManagedMembers(contents, d.Get("content").([]interface{}))
+	}
 
+	err = d.Set("etag", module.ETag)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set etag",
+			Detail:   fmt.Sprintf("Error when setting etag: %s", err.Error()),
+		})
+	}
 	err = d.Set("name", module.Name)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -197,7 +406,7 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("Error when setting type: %s", err.Error()),
 		})
 	}
-	err = d.Set("tags", module.Tags)
+	err = d.Set("tags", removeDefaultTags(module.Tags, c.DefaultTags, d.Get("tags").([]interface{})))
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -205,6 +414,14 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("Error when setting tags: %s", err.Error()),
 		})
 	}
+	err = d.Set("tags_all", module.Tags)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set tags_all",
+			Detail:   fmt.Sprintf("Error when setting tags_all: %s", err.Error()),
+		})
+	}
 	err = d.Set("reward", module.Reward)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -213,6 +430,26 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("Error when setting reward: %s", err.Error()),
 		})
 	}
+	for key, val := range deserializeAudit(module.Audit) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+		}
+	}
+	for key, val := range deserializeModuleData(module.Data) {
+		err = d.Set(key, val)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to set %s", key),
+				Detail:   fmt.Sprintf("Error when setting %s: %s", key, err.Error()),
+			})
+		}
+	}
 	err = d.Set("module", modules)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -238,6 +475,68 @@ func resourceModuleUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	c := m.(*pc.Client)
 
+	request := serializeModuleChanges(d, c.DefaultTags)
+
+	etag, _ := d.GetChange("etag")
+
+	var err error
+	if d.Get("ignore_external_members").(bool) && (request.Modules != nil || request.Contents != nil) {
+		oldModules, _ := d.GetChange("module")
+		oldContents, _ := d.GetChange("content")
+
+		// The members attached outside of the resource change the etag of the module, so the update is only
+		// checked against the version it is computed from
+		_, err = c.UpdateModuleMembers(d.Id(), func(current *module.Module) module.UpdateModuleRequest {
+			if request.Modules != nil {
+				modules := mergeExternalMembers(*current.FlattenModuleIdentifiers(), oldModules.([]interface{}), *request.Modules)
+				request.Modules = &modules
+			}
+			if request.Contents != nil {
+				contents := mergeExternalMembers(*current.FlattenContentIdentifiers(), oldContents.([]interface{}), *request.Contents)
+				request.Contents = &contents
+			}
+
+			return request
+		})
+	} else {
+		_, err = c.PatchModule(d.Id(), request, etag.(string))
+	}
+	if err != nil {
+		diags = append(diags, refreshFailedOperation(ctx, d, m, err, resourceModuleRead)...)
+		if validationDiags := validationDiagnostics(d, "Invalid module", err, moduleAttributePath); len(validationDiags) > 0 {
+			return append(diags, validationDiags...)
+		}
+		diags = append(diags, updateErrorDiagnostic("module", d.Id(), err))
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updated Module %s", d.Id()))
+
+	return resourceModuleRead(ctx, d, m)
+}
+
+func resourceModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*pc.Client)
+
+	err := c.DeleteModule(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete Module",
+			Detail:   fmt.Sprintf("Error when deleting Module: %s", err.Error()),
+		})
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleted Module %s", d.Id()))
+
+	return diags
+}
+
+// `serializeModule` reads the attributes of a module and returns them as a module.Module struct
+func serializeModule(d resourceGetter, defaultTags []string) module.Module {
 	modules := make([]module.ModuleIdentifier, 0)
 	for _, v := range d.Get("module").([]interface{}) {
 		modules = append(modules, module.ModuleIdentifier{
@@ -255,58 +554,183 @@ func resourceModuleUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		tags = append(tags, v.(string))
 	}
 
-	mo := module.Module{
-		ID:          d.Id(),
+	return module.Module{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Type:        d.Get("type").(string),
-		Tags:        tags,
+		Tags:        module.MergeTags(tags, defaultTags),
 		Reward:      int64(d.Get("reward").(int)),
+		Data:        serializeModuleData(d),
 		Modules:     modules,
 		Contents:    contents,
 	}
+}
 
-	_, err := c.UpdateModule(mo)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update module",
-			Detail:   fmt.Sprintf("Error when updating module: %s", err.Error()),
-		})
-		return diags
+// `serializeModuleData` reads the module metadata attributes and returns them as a module.ModuleData struct,
+// dates are validated at plan time so parsing errors are ignored
+func serializeModuleData(d resourceGetter) module.ModuleData {
+	data := module.ModuleData{
+		Visibility:       d.Get("visibility").(string),
+		Difficulty:       d.Get("difficulty").(string),
+		EstimatedMinutes: int64(d.Get("estimated_minutes").(int)),
 	}
 
-	err = d.Set("last_update", time.Now().Format(time.RFC850))
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to set last update",
-			Detail:   fmt.Sprintf("Error when setting last update: %s", err.Error()),
-		})
-		return diags
+	if v := d.Get("starts_at").(string); v != "" {
+		startsAt, err := time.Parse(time.RFC3339, v)
+		if err == nil {
+			data.StartsAt = &startsAt
+		}
+	}
+	if v := d.Get("ends_at").(string); v != "" {
+		endsAt, err := time.Parse(time.RFC3339, v)
+		if err == nil {
+			data.EndsAt = &endsAt
+		}
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Updated Module %s", d.Id()))
+	return data
+}
 
-	return resourceModuleRead(ctx, d, m)
+// `deserializeModuleData` takes a module.ModuleData and converts it into the module metadata attributes
+func deserializeModuleData(data module.ModuleData) map[string]interface{} {
+	result := map[string]interface{}{
+		"visibility":        data.Visibility,
+		"difficulty":        data.Difficulty,
+		"estimated_minutes": data.EstimatedMinutes,
+		"starts_at":         "",
+		"ends_at":           "",
+	}
+
+	if data.StartsAt != nil {
+		result["starts_at"] = data.StartsAt.Format(time.RFC3339)
+	}
+	if data.EndsAt != nil {
+		result["ends_at"] = data.EndsAt.Format(time.RFC3339)
+	}
+
+	return result
 }
 
-func resourceModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
+// `serializeModuleChanges` returns an update request holding only the module attributes that changed,
+// so that lists managed by other workspaces are not rewritten when they did not change
+func serializeModuleChanges(d *schema.ResourceData, defaultTags []string) module.UpdateModuleRequest {
+	request := module.UpdateModuleRequest{}
 
-	c := m.(*pc.Client)
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		request.Name = &name
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		request.Description = &description
+	}
+	if d.HasChange("type") {
+		moduleType := d.Get("type").(string)
+		request.Type = &moduleType
+	}
+	if d.HasChange("reward") {
+		reward := int64(d.Get("reward").(int))
+		request.Reward = &reward
+	}
+	if d.HasChanges("tags", "tags_all") {
+		tags := make([]string, 0)
+		for _, v := range d.Get("tags").([]interface{}) {
+			tags = append(tags, v.(string))
+		}
+		tags = module.MergeTags(tags, defaultTags)
+		request.Tags = &tags
+	}
+	if d.HasChanges("visibility", "difficulty", "estimated_minutes", "starts_at", "ends_at") {
+		data := serializeModuleData(d)
+		request.Data = data.IntoUpdateModuleRequestData()
+	}
+	if d.HasChange("module") {
+		modules := make([]string, 0)
+		for _, v := range d.Get("module").([]interface{}) {
+			modules = append(modules, v.(string))
+		}
+		request.Modules = &modules
+	}
+	if d.HasChange("content") {
+		contents := make([]string, 0)
+		for _, v := range d.Get("content").([]interface{}) {
+			contents = append(contents, v.(string))
+		}
+		request.Contents = &contents
+	}
 
-	err := c.DeleteModule(d.Id())
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Module",
-			Detail:   fmt.Sprintf("Error when deleting Module: %s", err.Error()),
-		})
-		return diags
+	return request
+}
+
+// `keepManagedMembers` returns the members of a module that are managed by the resource, in the API order
+func keepManagedMembers(current []string, managed []interface{}) []string {
+	result := make([]string, 0)
+
+	for _, ID := range current {
+		for _, v := range managed {
+			if v.(string) == ID {
+				result = append(result, ID)
+				break
+			}
+		}
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Deleted Module %s", d.Id()))
+	return result
+}
 
-	return diags
+// `mergeExternalMembers` returns the new members of a module followed by the members attached outside of the resource,
+// the external members being the current ones that were not previously managed by the resource
+func mergeExternalMembers(current []string, previous []interface{}, next []string) []string {
+	result := make([]string, 0)
+	result = append(result, next...)
+
+	for _, ID := range current {
+		external := true
+		for _, v := range previous {
+			if v.(string) == ID {
+				external = false
+				break
+			}
+		}
+		for _, v := range result {
+			if v == ID {
+				external = false
+				break
+			}
+		}
+
+		if external {
+			result = append(result, ID)
+		}
+	}
+
+	return result
+}
+
+// `removeDefaultTags` returns the tags of a module without the provider default tags,
+// unless they are also part of the configured tags
+func removeDefaultTags(tags []string, defaultTags []string, configured []interface{}) []string {
+	result := make([]string, 0)
+
+	for _, tag := range tags {
+		keep := true
+		for _, v := range defaultTags {
+			if v == tag {
+				keep = false
+				break
+			}
+		}
+		for _, v := range configured {
+			if v.(string) == tag {
+				keep = true
+				break
+			}
+		}
+
+		if keep {
+			result = append(result, tag)
+		}
+	}
+
+	return result
 }
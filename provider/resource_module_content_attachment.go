@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceModuleContentAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceModuleContentAttachmentCreate,
+		ReadContext:   resourceModuleContentAttachmentRead,
+		DeleteContext: resourceModuleContentAttachmentDelete,
+		Schema: map[string]*schema.Schema{
+			"module_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the module the content is attached to",
+			},
+			"content_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the attached content",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceModuleAttachmentImport("content_id"),
+		},
+	}
+}
+
+func resourceModuleContentAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	contentID := d.Get("content_id").(string)
+
+	err := c.AttachContent(moduleID, contentID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to attach content",
+			Detail:   fmt.Sprintf("Error when attaching content %s to module %s: %s", contentID, moduleID, err.Error()),
+		})
+		return diags
+	}
+
+	d.SetId(moduleAttachmentID(moduleID, contentID))
+
+	tflog.Info(ctx, fmt.Sprintf("Attached Content %s to Module %s", contentID, moduleID))
+
+	return resourceModuleContentAttachmentRead(ctx, d, m)
+}
+
+func resourceModuleContentAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	contentID := d.Get("content_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading Content attachment %s", d.Id()))
+
+	module, err := c.GetModule(moduleID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get Module",
+			Detail:   fmt.Sprintf("Error when getting Module: %s", err.Error()),
+		})
+		return diags
+	}
+
+	for _, content := range module.Contents {
+		if content.ID == contentID {
+			return diags
+		}
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Content %s is no longer attached to Module %s, removing it from state", contentID, moduleID))
+	d.SetId("")
+
+	return diags
+}
+
+func resourceModuleContentAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	contentID := d.Get("content_id").(string)
+
+	err := c.DetachContent(moduleID, contentID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to detach content",
+			Detail:   fmt.Sprintf("Error when detaching content %s from module %s: %s", contentID, moduleID, err.Error()),
+		})
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Detached Content %s from Module %s", contentID, moduleID))
+
+	return diags
+}
+
+// `moduleAttachmentID` builds the ID of an attachment resource from the module ID and the member ID
+func moduleAttachmentID(moduleID string, memberID string) string {
+	return fmt.Sprintf("%s/%s", moduleID, memberID)
+}
+
+// `resourceModuleAttachmentImport` returns an importer reading an attachment ID of the form
+// `<module_id>/<member_id>` and setting the member ID in the given attribute
+func resourceModuleAttachmentImport(memberKey string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid attachment id %q, expected <module_id>/<%s>", d.Id(), memberKey)
+		}
+
+		err := d.Set("module_id", parts[0])
+		if err != nil {
+			return nil, err
+		}
+		err = d.Set(memberKey, parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
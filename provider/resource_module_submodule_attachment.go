@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	pc "polycode-provider/client"
+	"polycode-provider/client/models/module"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceModuleSubmoduleAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceModuleSubmoduleAttachmentCreate,
+		ReadContext:   resourceModuleSubmoduleAttachmentRead,
+		DeleteContext: resourceModuleSubmoduleAttachmentDelete,
+		Schema: map[string]*schema.Schema{
+			"module_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the module the submodule is attached to",
+			},
+			"submodule_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the attached submodule",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceModuleAttachmentImport("submodule_id"),
+		},
+	}
+}
+
+func resourceModuleSubmoduleAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	submoduleID := d.Get("submodule_id").(string)
+
+	parent, err := c.GetModule(moduleID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get Module",
+			Detail:   fmt.Sprintf("Error when getting Module: %s", err.Error()),
+		})
+		return diags
+	}
+
+	parent.Modules = append(parent.Modules, module.ModuleIdentifier{ID: submoduleID})
+
+	err = c.ValidateModuleGraph(*parent)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid module dependency graph",
+			Detail:   fmt.Sprintf("Unable to attach submodule %s to module %s: %s", submoduleID, moduleID, err.Error()),
+		})
+		return diags
+	}
+
+	err = c.AttachSubmodule(moduleID, submoduleID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to attach submodule",
+			Detail:   fmt.Sprintf("Error when attaching submodule %s to module %s: %s", submoduleID, moduleID, err.Error()),
+		})
+		return diags
+	}
+
+	d.SetId(moduleAttachmentID(moduleID, submoduleID))
+
+	tflog.Info(ctx, fmt.Sprintf("Attached Submodule %s to Module %s", submoduleID, moduleID))
+
+	return resourceModuleSubmoduleAttachmentRead(ctx, d, m)
+}
+
+func resourceModuleSubmoduleAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	submoduleID := d.Get("submodule_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading Submodule attachment %s", d.Id()))
+
+	parent, err := c.GetModule(moduleID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get Module",
+			Detail:   fmt.Sprintf("Error when getting Module: %s", err.Error()),
+		})
+		return diags
+	}
+
+	for _, submodule := range parent.Modules {
+		if submodule.ID == submoduleID {
+			return diags
+		}
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Submodule %s is no longer attached to Module %s, removing it from state", submoduleID, moduleID))
+	d.SetId("")
+
+	return diags
+}
+
+func resourceModuleSubmoduleAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*pc.Client)
+
+	var diags diag.Diagnostics
+
+	moduleID := d.Get("module_id").(string)
+	submoduleID := d.Get("submodule_id").(string)
+
+	err := c.DetachSubmodule(moduleID, submoduleID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to detach submodule",
+			Detail:   fmt.Sprintf("Error when detaching submodule %s from module %s: %s", submoduleID, moduleID, err.Error()),
+		})
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Detached Submodule %s from Module %s", submoduleID, moduleID))
+
+	return diags
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// `moduleServer` serves a module whose contents can be attached outside of the resource, rejecting the updates
+// that are not made on its current version.
+type moduleServer struct {
+	mu       sync.Mutex
+	version  int
+	contents []string
+}
+
+func (s *moduleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == "PATCH" {
+		if r.Header.Get("If-Match") != s.etag() {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"message":"The module changed"}`))
+			return
+		}
+		var request struct {
+			Contents []string `json:"contents"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		s.contents = request.Contents
+		s.version++
+	}
+
+	contents := make([]string, 0)
+	for _, ID := range s.contents {
+		contents = append(contents, fmt.Sprintf(`{"id":%q}`, ID))
+	}
+	w.Header().Set("ETag", s.etag())
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"metadata":{},"data":{"id":"m1","name":"Module","description":"A module","type":"practice","reward":10,"tags":[],"data":{},"modules":[],"contents":[%s]}}`, strings.Join(contents, ","))))
+}
+
+func (s *moduleServer) etag() string {
+	return fmt.Sprintf(`"v%d"`, s.version)
+}
+
+func moduleState(contents ...string) *terraform.InstanceState {
+	state := &terraform.InstanceState{
+		ID: "m1",
+		Attributes: map[string]string{
+			"id":                      "m1",
+			"etag":                    `"v1"`,
+			"name":                    "Module",
+			"description":             "A module",
+			"type":                    "practice",
+			"reward":                  "10",
+			"tags.#":                  "0",
+			"tags_all.#":              "0",
+			"module.#":                "0",
+			"content.#":               fmt.Sprint(len(contents)),
+			"ignore_external_members": "true",
+		},
+	}
+	for i, ID := range contents {
+		state.Attributes[fmt.Sprintf("content.%d", i)] = ID
+	}
+
+	return state
+}
+
+func TestModuleUpdateKeepsExternalMembers(t *testing.T) {
+	server := &moduleServer{version: 1, contents: []string{"c1"}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	c := &pc.Client{Host: httpServer.URL, HTTPClient: httpServer.Client()}
+	r := resourceModule()
+
+	// The content is attached outside of the resource after the state was last refreshed
+	if err := c.AttachContent("m1", "c2"); err != nil {
+		t.Fatalf("Error attaching content: %s", err)
+	}
+
+	state := moduleState("c1")
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                    "Module",
+		"description":             "A module",
+		"type":                    "practice",
+		"reward":                  10,
+		"tags":                    []interface{}{},
+		"content":                 []interface{}{"c1", "c3"},
+		"ignore_external_members": true,
+	})
+
+	diff, err := r.SimpleDiff(context.Background(), state, config, c)
+	if err != nil {
+		t.Fatalf("Error planning module: %s", err)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, c)
+	if diags.HasError() {
+		t.Fatalf("Error updating module: %v", diags)
+	}
+
+	if strings.Join(server.contents, ",") != "c1,c3,c2" {
+		t.Errorf("Expected the external content to be kept, got %v", server.contents)
+	}
+	if newState.Attributes["content.#"] != "2" || newState.Attributes["content.1"] != "c3" {
+		t.Errorf("Expected only the managed contents in the state, got %v", newState.Attributes)
+	}
+}
+
+func TestModuleImportKeepsMembers(t *testing.T) {
+	server := &moduleServer{version: 1, contents: []string{"c1", "c2"}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	c := &pc.Client{Host: httpServer.URL, HTTPClient: httpServer.Client()}
+	r := resourceModule()
+
+	state := &terraform.InstanceState{
+		ID:         "m1",
+		Attributes: map[string]string{"id": "m1", "ignore_external_members": "true"},
+	}
+
+	newState, diags := r.RefreshWithoutUpgrade(context.Background(), state, c)
+	if diags.HasError() {
+		t.Fatalf("Error reading module: %v", diags)
+	}
+
+	if newState.Attributes["content.#"] != "2" {
+		t.Errorf("Expected the contents of the imported module to be kept, got %v", newState.Attributes)
+	}
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// `tracerName` is the name of the tracer of the provider operations.
+const tracerName = "polycode-provider/provider"
+
+// `SetupTracing` exports the OpenTelemetry spans of the provider and of its API calls, depending on the
+// `OTEL_TRACES_EXPORTER` environment variable:
+//   - `otlp` sends them to an OTLP/HTTP collector, configured with the standard `OTEL_EXPORTER_OTLP_*`
+//     variables (e.g. `OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318`).
+//   - `stdout` writes them as JSON to the file in `POLYCODE_TRACES_FILE`, or to the standard error since
+//     the standard output of the provider is used by Terraform.
+//   - `none` or unset disables tracing.
+//
+// @param ctx - The context of the provider process.
+// @returns - The function flushing the spans left before the provider exits.
+// @returns {error} - An error if the exporter is unknown or could not be created.
+func SetupTracing(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+
+	switch name := strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")); name {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		otlpExporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		exporter = otlpExporter
+	case "stdout", "console":
+		var writer io.Writer = os.Stderr
+		if path := os.Getenv("POLYCODE_TRACES_FILE"); path != "" {
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+			if err != nil {
+				return nil, err
+			}
+			writer = file
+			closer = file
+		}
+
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, err
+		}
+		exporter = stdoutExporter
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q, expected otlp, stdout or none", name)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("polycode-provider"))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return func(ctx context.Context) error {
+		err := tracerProvider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// `traceResource` wraps the CRUD functions of a resource or a data source so that each operation is a span
+// and reports the warnings of the API.
+// @param {string} resourceType - The type of the resource, e.g. `polycode_item`.
+// @param {*schema.Resource} r - The resource to instrument.
+// @returns {*schema.Resource} - The same resource.
+func traceResource(resourceType string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = traceOperation(resourceType, "create", r.Timeouts != nil, r.CreateContext)
+	r.ReadContext = traceOperation(resourceType, "read", r.Timeouts != nil, r.ReadContext)
+	r.UpdateContext = traceOperation(resourceType, "update", r.Timeouts != nil, r.UpdateContext)
+	r.DeleteContext = traceOperation(resourceType, "delete", r.Timeouts != nil, r.DeleteContext)
+
+	return r
+}
+
+// `traceOperation` wraps a CRUD function in a span named after the resource type and the operation,
+// e.g. `polycode_item.create`, carrying the ID of the resource and the errors of the diagnostics.
+// The spans of the API calls made by the operation are children of this span, and the warnings and
+// deprecations returned by the API are added to the diagnostics of the operation. When the resource declares
+// timeouts, an operation failing because of its timeout tells how to increase it.
+func traceOperation(resourceType, operation string, timeouts bool, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx, span := otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s.%s", resourceType, operation),
+			trace.WithAttributes(
+				attribute.String("polycode.resource.type", resourceType),
+				attribute.String("polycode.resource.operation", operation),
+			),
+		)
+		defer span.End()
+
+		// The requests of the client are sent, logged and traced within the span of the operation, they are
+		// canceled when the timeout of the operation is reached.
+		var notices *pc.Notices
+		if c, ok := m.(*pc.Client); ok {
+			c = c.WithContext(ctx)
+			c.Notices = &pc.Notices{}
+			notices = c.Notices
+			m = c
+		}
+
+		diags := f(ctx, d, m)
+
+		if timeouts && diags.HasError() && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Timeout while running the %s of %s", operation, resourceType),
+				Detail:   fmt.Sprintf("The %s did not complete within its timeout, it can be increased with the %s argument of the timeouts block of the resource.", operation, operation),
+			})
+		}
+
+		for _, notice := range notices.List() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  notice.Summary,
+				Detail:   notice.Detail,
+			})
+		}
+
+		span.SetAttributes(attribute.String("polycode.resource.id", d.Id()))
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				span.SetStatus(codes.Error, diagnostic.Summary)
+				span.RecordError(fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail))
+			}
+		}
+
+		return diags
+	}
+}
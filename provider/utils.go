@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pc "polycode-provider/client"
+	"polycode-provider/client/shared"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// `validateRFC3339` checks that an attribute is a RFC3339 date
+func validateRFC3339(val interface{}, key string) (warns []string, errs []error) {
+	_, err := time.Parse(time.RFC3339, val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a RFC3339 date (e.g. 2006-01-02T15:04:05Z): %s", key, err.Error()))
+	}
+	return
+}
+
+// `suppressEquivalentRFC3339` suppresses the diff between two RFC3339 dates pointing to the same instant,
+// the API may return a date in another time zone or with a different precision than the configuration
+func suppressEquivalentRFC3339(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+// `equalStrings` tells whether two string slices hold the same elements in the same order
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// `deserializeAudit` takes the audit information of a resource and converts it into the audit attributes,
+// the deprecated last_update attribute mirrors updated_at
+func deserializeAudit(audit shared.Audit) map[string]interface{} {
+	result := map[string]interface{}{
+		"created_at": "",
+		"updated_at": "",
+		"created_by": audit.CreatedBy,
+		"updated_by": audit.UpdatedBy,
+	}
+
+	if audit.CreatedAt != nil {
+		result["created_at"] = audit.CreatedAt.Format(time.RFC3339)
+	}
+	if audit.UpdatedAt != nil {
+		result["updated_at"] = audit.UpdatedAt.Format(time.RFC3339)
+	}
+	result["last_update"] = result["updated_at"]
+
+	return result
+}
+
+// `computedOnUpdateDiff` marks the attributes the API changes on every update as unknown
+// when an existing resource is about to be updated, the updates read the etag last read with GetChange
+func computedOnUpdateDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+
+	for _, key := range []string{"etag", "updated_at", "updated_by", "last_update"} {
+		err := d.SetNewComputed(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// `updateErrorDiagnostic` converts an error returned when updating a resource into a diagnostic,
+// explaining how to recover when the resource was changed concurrently
+func updateErrorDiagnostic(resourceName string, ID string, err error) diag.Diagnostic {
+	if pc.IsAPIError(err, pc.APIErrorPreconditionFailed) {
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to update %s, it changed outside Terraform", resourceName),
+			Detail:   fmt.Sprintf("The %s %s was changed outside Terraform since it was last read, refresh and re-plan before applying again: %s", resourceName, ID, err.Error()),
+		}
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Unable to update %s", resourceName),
+		Detail:   fmt.Sprintf("Error when updating %s: %s", resourceName, err.Error()),
+	}
+}
+
+// `logCacheStats` logs the number of reads served from the cache of the client and sent to the API
+func logCacheStats(ctx context.Context, c *pc.Client) {
+	if c.Cache == nil {
+		return
+	}
+
+	hits, misses := c.Cache.Stats()
+	tflog.Debug(ctx, fmt.Sprintf("Client cache: %d hits, %d misses", hits, misses))
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	pc "polycode-provider/client"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// `contentFieldAttributes` maps the fields of a content request body to the attributes of polycode_content
+var contentFieldAttributes = map[string]string{
+	"name":               "name",
+	"description":        "description",
+	"type":               "type",
+	"reward":             "reward",
+	"difficulty":         "difficulty",
+	"estimatedMinutes":   "estimated_minutes",
+	"topics":             "topics",
+	"learningObjectives": "learning_objectives",
+}
+
+// `validatorFieldAttributes` maps the fields of a validator request body to the attributes of a validator block
+var validatorFieldAttributes = map[string]string{
+	"input":    "inputs",
+	"expected": "outputs",
+	"isHidden": "is_hidden",
+}
+
+// `languageFieldAttributes` maps the fields of a language request body to the attributes of a language_settings block
+var languageFieldAttributes = map[string]string{
+	"language":    "language",
+	"defaultCode": "default_code",
+	"version":     "version",
+}
+
+// `moduleFieldAttributes` maps the fields of a module request body to the attributes of polycode_module
+var moduleFieldAttributes = map[string]string{
+	"name":             "name",
+	"description":      "description",
+	"type":             "type",
+	"reward":           "reward",
+	"tags":             "tags",
+	"modules":          "module",
+	"contents":         "content",
+	"visibility":       "visibility",
+	"difficulty":       "difficulty",
+	"estimatedMinutes": "estimated_minutes",
+	"startsAt":         "starts_at",
+	"endsAt":           "ends_at",
+}
+
+// `attributePathFunc` maps the path of a field of a request body, split by pc.SplitValidationPath, to the path of
+// the attribute it comes from, and tells whether the whole path was mapped
+type attributePathFunc func(d resourceGetter, steps []interface{}) (cty.Path, bool)
+
+// `validationDiagnostics` turns the validation errors of a resource rejected by the API into one diagnostic
+// per invalid field, pointing to the attribute of the configuration the field comes from
+// @returns {diag.Diagnostics} - The diagnostics, empty if the error does not hold validation errors
+func validationDiagnostics(d resourceGetter, summary string, err error, mapPath attributePathFunc) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, validationError := range pc.ValidationErrors(err) {
+		path, mapped := mapPath(d, pc.SplitValidationPath(validationError.Path))
+
+		detail := validationError.Message
+		if validationError.Path != "" && (!mapped || len(path) == 0) {
+			detail = fmt.Sprintf("%s: %s", validationError.Path, validationError.Message)
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path,
+		})
+	}
+
+	// The failure of an asynchronous operation is reported with the fields it is about.
+	var operationErr *pc.OperationError
+	if len(diags) > 0 && errors.As(err, &operationErr) && operationErr.Message != "" {
+		diags = append(diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("Operation %s failed: %s", operationErr.OperationID, operationErr.Message),
+		}}, diags...)
+	}
+
+	return diags
+}
+
+// `planValidationError` turns the error of the validation of a resource by the API into the error of a
+// CustomizeDiff, which cannot return diagnostics, listing every invalid field with the attribute it comes from
+// @returns {error} - nil if the API accepted the resource
+func planValidationError(resourceName string, d resourceGetter, err error, mapPath attributePathFunc) error {
+	if err == nil {
+		return nil
+	}
+
+	validationErrors := pc.ValidationErrors(err)
+	if len(validationErrors) == 0 {
+		return fmt.Errorf("unable to validate the %s with the API: %w", resourceName, err)
+	}
+
+	lines := make([]string, 0)
+	for _, validationError := range validationErrors {
+		location := validationError.Path
+		if path, _ := mapPath(d, pc.SplitValidationPath(validationError.Path)); len(path) > 0 {
+			location = formatAttributePath(path)
+		}
+
+		if location == "" {
+			lines = append(lines, fmt.Sprintf("  - %s", validationError.Message))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  - %s: %s", location, validationError.Message))
+	}
+
+	return fmt.Errorf("the API rejected the %s:\n%s", resourceName, strings.Join(lines, "\n"))
+}
+
+// `formatAttributePath` writes the path of an attribute the way Terraform addresses it in a plan,
+// e.g. `container.0.editor.0.validator.0.outputs`
+func formatAttributePath(path cty.Path) string {
+	steps := make([]string, 0)
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			steps = append(steps, step.Name)
+		case cty.IndexStep:
+			index, _ := step.Key.AsBigFloat().Int64()
+			steps = append(steps, fmt.Sprintf("%d", index))
+		}
+	}
+
+	return strings.Join(steps, ".")
+}
+
+// `newValuesKnown` tells whether the planned values of the attributes, and of every attribute nested in them,
+// are known
+func newValuesKnown(d *schema.ResourceDiff, keys ...string) bool {
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+		for _, nested := range d.GetChangedKeysPrefix(key) {
+			if !d.NewValueKnown(nested) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// `moduleAttributePath` maps the path of a field of a module request body to the path of the attribute it
+// comes from, e.g. `data.startsAt` to `starts_at`
+func moduleAttributePath(d resourceGetter, steps []interface{}) (cty.Path, bool) {
+	if len(steps) > 1 && steps[0] == "data" {
+		steps = steps[1:]
+	}
+	if len(steps) == 0 {
+		return nil, false
+	}
+
+	if name, ok := steps[0].(string); ok && moduleFieldAttributes[name] != "" {
+		return attributePath(cty.GetAttrPath(moduleFieldAttributes[name]), steps[1:])
+	}
+
+	return nil, false
+}
+
+// `contentAttributePath` maps the path of a field of a content request body to the path of the attribute it
+// comes from, e.g. `rootComponent.data.components[1].data.validators[0].expected` to
+// `container.0.editor.0.validator.0.outputs` when the editor is the component at position 2
+// @returns {cty.Path} - The path of the attribute, or of the closest attribute that could be found
+// @returns {bool} - Whether the whole path was mapped
+func contentAttributePath(d resourceGetter, steps []interface{}) (cty.Path, bool) {
+	if len(steps) == 0 {
+		return nil, false
+	}
+
+	switch steps[0] {
+	case "data":
+		if len(steps) > 1 {
+			if name, ok := steps[1].(string); ok && contentFieldAttributes[name] != "" {
+				return attributePath(cty.GetAttrPath(contentFieldAttributes[name]), steps[2:])
+			}
+		}
+	case "rootComponent":
+		switch {
+		case d.Get("definition_json").(string) != "":
+			return cty.GetAttrPath("definition_json"), false
+		case d.Get("source_dir").(string) != "":
+			return cty.GetAttrPath("source_dir"), false
+		}
+
+		container, ok := d.Get("container.0").(map[string]interface{})
+		if !ok {
+			return cty.GetAttrPath("container"), false
+		}
+		return componentAttributePath(cty.GetAttrPath("container").IndexInt(0), container, steps[1:])
+	default:
+		if name, ok := steps[0].(string); ok && contentFieldAttributes[name] != "" {
+			return attributePath(cty.GetAttrPath(contentFieldAttributes[name]), steps[1:])
+		}
+	}
+
+	return nil, false
+}
+
+// `componentAttributePath` maps the path of a field inside a component to the path of the attribute it comes from
+// @param {cty.Path} path - The path of the block of the component
+// @param {map[string]interface{}} component - The attributes of the block of the component
+// @param {[]interface{}} steps - The path of the field relative to the component
+func componentAttributePath(path cty.Path, component map[string]interface{}, steps []interface{}) (cty.Path, bool) {
+	if len(steps) == 0 {
+		return path, true
+	}
+	if steps[0] != "data" || len(steps) < 2 {
+		return path, false
+	}
+
+	switch steps[1] {
+	case "components":
+		if len(steps) < 3 {
+			return path, false
+		}
+		index, ok := steps[2].(int)
+		if !ok {
+			return path, false
+		}
+
+		// The child components are the blocks of the container sorted by their position.
+		for _, key := range []string{"markdown", "editor", "container"} {
+			children, _ := component[key].([]interface{})
+			for i, child := range children {
+				child, ok := child.(map[string]interface{})
+				if !ok || child["position"] != index+1 {
+					continue
+				}
+				return componentAttributePath(path.GetAttr(key).IndexInt(i), child, steps[3:])
+			}
+		}
+		return path, false
+	case "orientation":
+		return attributePath(path.GetAttr("orientation"), steps[2:])
+	case "markdown":
+		return attributePath(path.GetAttr("content"), steps[2:])
+	case "items":
+		return attributePath(path.GetAttr("hint"), steps[2:])
+	case "validators":
+		return listAttributePath(path.GetAttr("validator"), validatorFieldAttributes, steps[2:])
+	case "editorSettings":
+		if len(steps) > 2 && steps[2] == "languages" {
+			return listAttributePath(path.GetAttr("language_settings"), languageFieldAttributes, steps[3:])
+		}
+	}
+
+	return path, false
+}
+
+// `listAttributePath` maps the path of a field inside a list of blocks, e.g. `[0].expected` to `0.outputs`
+// @param {map[string]string} fields - The attributes of the blocks by field name
+func listAttributePath(path cty.Path, fields map[string]string, steps []interface{}) (cty.Path, bool) {
+	if len(steps) == 0 {
+		return path, true
+	}
+	index, ok := steps[0].(int)
+	if !ok {
+		return path, false
+	}
+	path = path.IndexInt(index)
+
+	if len(steps) == 1 {
+		return path, true
+	}
+	name, ok := steps[1].(string)
+	if !ok || fields[name] == "" {
+		return path, false
+	}
+
+	// The inputs and outputs of a validator are nested in the input.stdin and expected.stdout fields.
+	remaining := steps[2:]
+	if len(remaining) > 0 && (remaining[0] == "stdin" || remaining[0] == "stdout") {
+		remaining = remaining[1:]
+	}
+
+	return attributePath(path.GetAttr(fields[name]), remaining)
+}
+
+// `attributePath` maps the path of a field inside an attribute, only list indexes can follow an attribute
+func attributePath(path cty.Path, steps []interface{}) (cty.Path, bool) {
+	if len(steps) == 0 {
+		return path, true
+	}
+
+	index, ok := steps[0].(int)
+	if !ok {
+		return path, false
+	}
+
+	return path.IndexInt(index), len(steps) == 1
+}